@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/seblex/testdoc"
+	"github.com/seblex/testdoc/pkg/results"
+)
+
+// runBadge выполняет команду badge: записывает SVG бейджи метрик тестов
+func runBadge(args []string) {
+	flags := flag.NewFlagSet("badge", flag.ExitOnError)
+	var (
+		outputDir   = flags.String("output", "", "Директория для бейджей (по умолчанию badges.dir из конфигурации)")
+		configFile  = flags.String("config", "", "Файл конфигурации YAML (опционально)")
+		resultsFile = flags.String("results", "", "Файл с выводом go test -json для бейджа доли прошедших тестов")
+	)
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Использование: %s badge [опции] [путь]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Записывает SVG бейджи: тесты, пропущено, аннотации, количество по типам и доля прошедших.\n\n")
+		fmt.Fprintf(os.Stderr, "Опции:\n")
+		flags.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nПримеры:\n")
+		fmt.Fprintf(os.Stderr, "  %s badge .                                 # Бейджи в директорию badges\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  go test -json ./... > results.json\n")
+		fmt.Fprintf(os.Stderr, "  %s badge -results results.json -output .github/badges .\n", os.Args[0])
+	}
+	flags.Parse(args)
+
+	path := "."
+	if flags.NArg() > 0 {
+		path = flags.Arg(0)
+	}
+
+	config := loadConfig(*configFile, path)
+	var err error
+
+	if err := testdoc.ValidateConfig(config); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка валидации конфигурации: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *outputDir == "" {
+		*outputDir = config.Badges.Dir
+	}
+
+	var report *results.Report
+	if *resultsFile != "" {
+		report, err = results.Load(*resultsFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка загрузки результатов тестов: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	result, err := testdoc.ParseDirectory(path, config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка анализа тестов: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := testdoc.WriteBadges(result, *outputDir, config, report); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка записи бейджей: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("🏷️ Бейджи записаны: %s\n", *outputDir)
+}
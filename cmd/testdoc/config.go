@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/seblex/testdoc"
+	"github.com/seblex/testdoc/pkg/config"
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// loadConfig собирает конфигурацию для директории path: значения по умолчанию,
+// пользовательский файл, .testdoc.yaml репозитория или файл -config и
+// переменные окружения TESTDOC_*. При ошибке загрузки или неверных значениях
+// завершает программу, указывая файл, строку и столбец ошибки.
+func loadConfig(configFile, path string) *types.Config {
+	effective := resolveConfig(configFile, path)
+	if err := effective.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка валидации конфигурации:\n%v\n", err)
+		os.Exit(1)
+	}
+	return effective.Config
+}
+
+// resolveConfig собирает конфигурацию без проверки значений. При ошибке
+// завершает программу.
+func resolveConfig(configFile, path string) *config.Effective {
+	effective, err := testdoc.ResolveConfig(path, configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка загрузки конфигурации:\n%v\n", err)
+		os.Exit(1)
+	}
+	return effective
+}
+
+// configUsage печатает список подкоманд config
+func configUsage() {
+	fmt.Fprintf(os.Stderr, "Использование: %s config <команда> [опции] [путь]\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Команды:\n")
+	fmt.Fprintf(os.Stderr, "  show      итоговая конфигурация с источником каждого значения\n")
+	fmt.Fprintf(os.Stderr, "  validate  проверка конфигурации с указанием строки и столбца ошибок\n")
+	fmt.Fprintf(os.Stderr, "  schema    JSON Schema файла конфигурации для редакторов\n")
+}
+
+// runConfig выполняет команду config
+func runConfig(args []string) {
+	if len(args) == 0 {
+		configUsage()
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "show", "validate":
+	case "schema":
+		os.Stdout.Write(config.Schema())
+		return
+	default:
+		configUsage()
+		os.Exit(2)
+	}
+
+	command := args[0]
+	flags := flag.NewFlagSet("config "+command, flag.ExitOnError)
+	configFile := flags.String("config", "", "Файл конфигурации YAML вместо поиска .testdoc.yaml")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Использование: %s config %s [опции] [путь]\n\n", os.Args[0], command)
+		if command == "show" {
+			fmt.Fprintf(os.Stderr, "Печатает итоговую конфигурацию для директории с источником каждого значения.\n")
+		} else {
+			fmt.Fprintf(os.Stderr, "Проверяет итоговую конфигурацию для директории: неизвестные ключи,\n")
+			fmt.Fprintf(os.Stderr, "допустимые значения и синтаксис паттернов.\n")
+		}
+		fmt.Fprintf(os.Stderr, "Слои: по умолчанию < пользовательский файл < .testdoc.yaml < TESTDOC_* < флаги.\n\n")
+		fmt.Fprintf(os.Stderr, "Опции:\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args[1:])
+
+	path := "."
+	if flags.NArg() > 0 {
+		path = flags.Arg(0)
+	}
+
+	if command == "validate" {
+		loadConfig(*configFile, path)
+		fmt.Println("Конфигурация корректна")
+		return
+	}
+
+	effective := resolveConfig(*configFile, path)
+
+	if len(effective.Files) > 0 {
+		fmt.Println("# Файлы конфигурации:")
+		for _, file := range effective.Files {
+			fmt.Printf("#   %s\n", file)
+		}
+	}
+	if err := effective.Write(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка вывода конфигурации: %v\n", err)
+		os.Exit(1)
+	}
+}
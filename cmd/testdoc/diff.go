@@ -0,0 +1,90 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/seblex/testdoc"
+	"github.com/seblex/testdoc/pkg/diff"
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// runDiff выполняет команду diff: сравнивает две инвентаризации тестов
+func runDiff(args []string) {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	var (
+		format     = flags.String("format", diff.FormatMarkdown, "Формат отчета: markdown, json или summary (комментарий к pull request)")
+		outputFile = flags.String("output", "", "Файл для отчета (по умолчанию стандартный вывод)")
+		configFile = flags.String("config", "", "Файл конфигурации YAML (опционально)")
+		path       = flags.String("path", ".", "Директория с тестами для сравнения git ревизий")
+		threshold  = flags.Float64("rename-threshold", diff.DefaultRenameThreshold, "Минимальное сходство тел 0..1 для признания теста переименованным")
+	)
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Использование: %s diff [опции] <было> <стало>\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Сравнивает две инвентаризации тестов. Каждая задается JSON файлом\n")
+		fmt.Fprintf(os.Stderr, "(testdoc -format json), директорией или git ревизией.\n\n")
+		fmt.Fprintf(os.Stderr, "Опции:\n")
+		flags.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nПримеры:\n")
+		fmt.Fprintf(os.Stderr, "  %s diff old.json new.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s diff -path ./internal main HEAD           # Две git ревизии\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s diff -format summary origin/main .        # Ревизия и рабочая копия\n", os.Args[0])
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if *threshold <= 0 || *threshold > 1 {
+		fmt.Fprintf(os.Stderr, "Ошибка: -rename-threshold должен быть в диапазоне (0, 1]\n")
+		os.Exit(1)
+	}
+
+	config := loadConfig(*configFile, *path)
+
+	err := testdoc.ValidateConfig(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка валидации конфигурации: %v\n", err)
+		os.Exit(1)
+	}
+
+	inventories := make([]*types.ParseResult, 2)
+	for i, source := range flags.Args() {
+		inventories[i], err = testdoc.LoadInventory(source, *path, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка загрузки инвентаризации %s: %v\n", source, err)
+			os.Exit(1)
+		}
+	}
+
+	report := testdoc.Diff(inventories[0], inventories[1], diff.Options{RenameThreshold: *threshold})
+
+	if *outputFile == "" {
+		err = report.Write(os.Stdout, *format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка записи отчета: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	file, err := os.Create(*outputFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка создания файла: %v\n", err)
+		os.Exit(1)
+	}
+
+	// os.Exit не выполняет отложенные вызовы, поэтому файл закрывается явно,
+	// а ошибка закрытия сообщается наравне с ошибкой записи
+	err = report.Write(file, *format)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка записи отчета: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -8,25 +8,61 @@ import (
 	"strings"
 
 	"github.com/seblex/testdoc"
+	"github.com/seblex/testdoc/pkg/generator"
+	"github.com/seblex/testdoc/pkg/results"
+	"github.com/seblex/testdoc/pkg/site"
 	"github.com/seblex/testdoc/pkg/types"
 )
 
 const version = "1.0.0"
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "badge" {
+		runBadge(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		runDiff(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		runConfig(os.Args[2:])
+		return
+	}
+
 	var (
 		outputFile   = flag.String("output", "test-documentation.md", "Файл для вывода документации")
-		configFile   = flag.String("config", "", "Файл конфигурации YAML (опционально)")
+		configFile   = flag.String("config", "", "Файл конфигурации YAML вместо поиска .testdoc.yaml (опционально)")
 		showVersion  = flag.Bool("version", false, "Показать версию")
 		showHelp     = flag.Bool("help", false, "Показать справку")
 		filterType   = flag.String("type", "", "Фильтр по типу тестов (unit, integration, functional, e2e, performance, security, regression, smoke)")
 		filterAuthor = flag.String("author", "", "Фильтр по автору")
 		filterTags   = flag.String("tags", "", "Фильтр по тегам (через запятую)")
+		filterOwner  = flag.String("owner", "", "Фильтр по владельцу из CODEOWNERS или @owner (например, @org/team)")
+		filterPrio   = flag.String("priority", "", "Фильтр по приоритетам @priority через запятую (например, P0,P1)")
+		filterSev    = flag.String("severity", "", "Фильтр по серьезности @severity через запятую (blocker, critical, major, minor, trivial)")
+		groupByOwner = flag.Bool("group-by-owner", false, "Группировать тесты по владельцам")
+		sortBy       = flag.String("sort-by", "", "Порядок тестов в разделах: "+strings.Join(generator.SortKeys(), ", "))
+		sortOrder    = flag.String("sort-order", "", "Направление сортировки: asc или desc")
+		resultsFile  = flag.String("results", "", "Файл с выводом go test -json: итоги и длительность запуска в документации")
+		groupBy      = flag.String("group-by", "", "Ключи вложенной группировки через запятую: "+strings.Join(generator.GroupKeys(), ", ")+", "+generator.GroupMetadataPrefix+"<ключ>")
+		traceability = flag.Bool("traceability", false, "Добавить матрицу трассируемости требований")
+		featuresDir  = flag.String("features", "", "Директория для экспорта сценариев в файлы Cucumber .feature")
+		charts       = flag.Bool("charts", false, "Встроить Mermaid диаграммы распределения и структуры тестов")
+		chartsDir    = flag.String("charts-dir", "", "Директория для записи диаграмм в файлы .mmd и .dot")
+		requirements = flag.String("requirements", "", "Файл со списком требований для матрицы трассируемости (по одному на строку)")
+		format       = flag.String("format", "", "Формат вывода ("+strings.Join(generator.Formats(), ", ")+", site)")
+		siteMode     = flag.String("site-mode", "", "Режим сайта для -format site: html или markdown (MkDocs/Hugo)")
+		ref          = flag.String("ref", "", "Git ревизия (ветка, тег, коммит): анализ тестов на ней без переключения рабочей копии")
+		minQuality   = flag.Float64("min-quality", 0, "Минимальная оценка качества документации 0..100; ниже - код выхода 1")
 	)
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "TestDoc v%s - Генератор документации для Go тестов\n\n", version)
-		fmt.Fprintf(os.Stderr, "Использование: %s [опции] [путь]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Использование: %s [опции] [путь]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "              %s badge [опции] [путь]  # SVG бейджи метрик тестов\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "              %s diff [опции] <было> <стало>  # Изменения тестов\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "              %s config show|validate|schema [путь]  # Итоговая конфигурация, проверка и JSON Schema\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "ВАЖНО: Все опции должны указываться ДО пути к директории!\n\n")
 		fmt.Fprintf(os.Stderr, "Опции:\n")
 		flag.PrintDefaults()
@@ -37,6 +73,18 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s -config config.yaml                # С конфигурацией\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -type unit -author 'John Doe'      # С фильтрами\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -tags 'api,database' ./internal    # Фильтр по тегам\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -owner @org/payments -group-by-owner # Тесты команды\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -priority P0,P1 -severity blocker .     # Критичные тесты перед релизом\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -group-by package,type .            # Вложенные разделы: пакет, затем тип\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -sort-by source .                    # Тесты в порядке исходного кода\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -results results.json -sort-by duration -sort-order desc .  # Сначала медленные\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -requirements reqs.txt .            # Матрица трассируемости\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -features features/ .               # Экспорт сценариев в .feature\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -ref v1.2.0 -output v1.2.0.md .     # Документация релизного тега\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -charts -charts-dir charts/ .        # Диаграммы в документе и в файлах\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -format json -output tests.json     # Вывод в JSON\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -min-quality 70 .                   # Проверка качества документации в CI\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -format site -output site .         # Многостраничный HTML сайт\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "\nПоддерживаемые типы тестов:\n")
 		for _, testType := range testdoc.GetSupportedTestTypes() {
 			fmt.Fprintf(os.Stderr, "  - %s\n", testType)
@@ -60,18 +108,71 @@ func main() {
 		path = flag.Arg(0)
 	}
 
-	// Загружаем конфигурацию
-	var config *types.Config
+	// Загружаем конфигурацию: значения по умолчанию, пользовательский файл,
+	// .testdoc.yaml или -config, переменные TESTDOC_*; флаги применяются ниже
+	config := loadConfig(*configFile, path)
 	var err error
 
-	if *configFile != "" {
-		config, err = testdoc.LoadConfig(*configFile)
+	if *format != "" {
+		config.Format = *format
+	}
+
+	if *siteMode != "" {
+		config.SiteMode = *siteMode
+	}
+
+	// Для сайта выходной путь по умолчанию - директория site
+	if config.Format == site.Format && !flagPassed("output") {
+		*outputFile = "site"
+	}
+
+	if *groupByOwner {
+		config.GroupByOwner = true
+		config.GroupBy = []string{generator.GroupOwner}
+	}
+
+	if *sortBy != "" {
+		config.SortBy = *sortBy
+	}
+
+	if *sortOrder != "" {
+		config.SortOrder = *sortOrder
+	}
+
+	if *groupBy != "" {
+		config.GroupBy = nil
+		for _, key := range strings.Split(*groupBy, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				config.GroupBy = append(config.GroupBy, key)
+			}
+		}
+	}
+
+	if *traceability {
+		config.Traceability = true
+	}
+
+	if *charts {
+		config.Charts = true
+	}
+
+	if *chartsDir != "" {
+		config.ChartsDir = *chartsDir
+	}
+
+	// -min-quality 0 отключает порог из конфигурации
+	if flagPassed("min-quality") {
+		config.MinQuality = *minQuality
+	}
+
+	if *requirements != "" {
+		known, err := testdoc.LoadRequirements(*requirements)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Ошибка загрузки конфигурации: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Ошибка загрузки требований: %v\n", err)
 			os.Exit(1)
 		}
-	} else {
-		config = testdoc.DefaultConfig()
+		config.Requirements = append(config.Requirements, known...)
+		config.Traceability = true
 	}
 
 	// Валидируем конфигурацию
@@ -82,12 +183,27 @@ func main() {
 	}
 
 	// Парсим тесты
-	result, err := testdoc.ParseDirectory(path, config)
+	var result *types.ParseResult
+	if *ref != "" {
+		result, err = testdoc.ParseRevision(path, *ref, config)
+	} else {
+		result, err = testdoc.ParseDirectory(path, config)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Ошибка анализа тестов: %v\n", err)
 		os.Exit(1)
 	}
 
+	// Итоги запуска тестов
+	if *resultsFile != "" {
+		report, err := results.Load(*resultsFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка чтения результатов тестов: %v\n", err)
+			os.Exit(1)
+		}
+		report.Attach(result)
+	}
+
 	// Применяем фильтры
 	if *filterType != "" {
 		testType := types.TestType(*filterType)
@@ -114,25 +230,76 @@ func main() {
 		result = filter.ByTags(result, tags)
 	}
 
+	if *filterOwner != "" {
+		filter := testdoc.NewFilter()
+		result = filter.ByOwner(result, *filterOwner)
+	}
+
+	if *filterPrio != "" {
+		var priorities []types.Priority
+		for _, value := range strings.Split(*filterPrio, ",") {
+			priority, ok := types.ParsePriority(value)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Неизвестный приоритет: %s\n", strings.TrimSpace(value))
+				fmt.Fprintf(os.Stderr, "Поддерживаемые приоритеты: %v\n", types.Priorities())
+				os.Exit(1)
+			}
+			priorities = append(priorities, priority)
+		}
+		filter := testdoc.NewFilter()
+		result = filter.ByPriority(result, priorities...)
+	}
+
+	if *filterSev != "" {
+		var severities []types.Severity
+		for _, value := range strings.Split(*filterSev, ",") {
+			severity, ok := types.ParseSeverity(value)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Неизвестная серьезность: %s\n", strings.TrimSpace(value))
+				fmt.Fprintf(os.Stderr, "Поддерживаемые уровни: %v\n", types.Severities())
+				os.Exit(1)
+			}
+			severities = append(severities, severity)
+		}
+		filter := testdoc.NewFilter()
+		result = filter.BySeverity(result, severities...)
+	}
+
 	// Проверяем, что найдены тесты
 	if result.Stats.TotalTests == 0 {
 		fmt.Fprintf(os.Stderr, "Не найдено тестов в директории: %s\n", path)
-		if *filterType != "" || *filterAuthor != "" || *filterTags != "" {
+		if *filterType != "" || *filterAuthor != "" || *filterTags != "" || *filterOwner != "" ||
+			*filterPrio != "" || *filterSev != "" {
 			fmt.Fprintf(os.Stderr, "Попробуйте изменить фильтры или проверить директорию.\n")
 		}
 		os.Exit(1)
 	}
 
-	// Генерируем документацию
-	markdown := testdoc.GenerateMarkdown(result, config)
-
-	// Сохраняем в файл
-	err = testdoc.WriteToFile(markdown, *outputFile)
+	// Генерируем документацию и сохраняем в файл
+	err = testdoc.RenderToFile(result, *outputFile, config)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Ошибка записи файла: %v\n", err)
 		os.Exit(1)
 	}
 
+	if *featuresDir != "" {
+		err = testdoc.WriteFeatures(result, *featuresDir, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка экспорта .feature файлов: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("🥒 Сценарии экспортированы: %s\n", *featuresDir)
+	}
+
+	if config.ChartsDir != "" {
+		err = testdoc.WriteCharts(result, config.ChartsDir, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка записи диаграмм: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("📈 Диаграммы записаны: %s\n", config.ChartsDir)
+	}
+
 	// Выводим статистику
 	fmt.Printf("✅ Документация успешно сгенерирована: %s\n", *outputFile)
 	fmt.Printf("📊 Статистика:\n")
@@ -148,7 +315,26 @@ func main() {
 			fmt.Printf("     * %s: %d (%.1f%%)\n", testType, count, percentage)
 		}
 	}
+	fmt.Printf("   - Качество документации: %.1f из 100\n", result.Stats.Quality.Score)
+
+	if config.MinQuality > 0 {
+		if err := testdoc.CheckQuality(result, config.MinQuality); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+	}
 
 	// Успешное завершение
 	os.Exit(0)
 }
+
+// flagPassed проверяет, был ли флаг указан в командной строке
+func flagPassed(name string) bool {
+	passed := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			passed = true
+		}
+	})
+	return passed
+}
@@ -0,0 +1,52 @@
+package testdoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/seblex/testdoc/pkg/diff"
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// LoadInventory загружает инвентаризацию тестов из source: JSON файла,
+// записанного с -format json, директории или git ревизии (ветки, тега,
+// коммита). Для git ревизии анализируется директория dir репозитория
+// в состоянии на эту ревизию; рабочая копия при этом не меняется.
+func LoadInventory(source, dir string, config *types.Config) (*types.ParseResult, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	if info, err := os.Stat(source); err == nil {
+		if info.IsDir() {
+			return ParseDirectory(source, config)
+		}
+		return loadInventoryJSON(source)
+	}
+
+	result, err := ParseRevision(dir, source, config)
+	if err != nil {
+		return nil, fmt.Errorf("%s не является файлом, директорией или git ревизией: %w", source, err)
+	}
+	return result, nil
+}
+
+// Diff сравнивает две инвентаризации тестов
+func Diff(before, after *types.ParseResult, opts diff.Options) *diff.Report {
+	return diff.Compare(before, after, opts)
+}
+
+// loadInventoryJSON читает инвентаризацию из JSON файла
+func loadInventoryJSON(filename string) (*types.ParseResult, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.ParseResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("ошибка чтения инвентаризации %s: %w", filename, err)
+	}
+	return &result, nil
+}
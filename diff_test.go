@@ -0,0 +1,115 @@
+package testdoc
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/diff"
+	"github.com/seblex/testdoc/pkg/generator"
+)
+
+const diffTestFile = `package sample
+
+import "testing"
+
+// TestLogin проверяет вход пользователя
+func TestLogin(t *testing.T) {
+	user := login("alice", "secret")
+	if user == nil {
+		t.Fatal("пользователь не найден")
+	}
+	if !user.Active {
+		t.Error("пользователь не активен")
+	}
+}
+
+func TestLegacy(t *testing.T) {
+	legacy(t)
+}
+`
+
+const diffTestFileRenamed = `package sample
+
+import "testing"
+
+// TestUserLogin проверяет вход пользователя
+func TestUserLogin(t *testing.T) {
+	user := login("alice", "secret")
+	if user == nil {
+		t.Fatal("пользователь не найден")
+	}
+	if !user.Active {
+		t.Error("пользователь не активен")
+	}
+}
+
+func TestLegacy(t *testing.T) {
+	t.Skip("устарел")
+	legacy(t)
+}
+`
+
+func TestLoadInventory_JSON(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sample_test.go"), []byte(diffTestFile), 0644))
+
+	result, err := ParseDirectory(dir, nil)
+	require.NoError(t, err)
+
+	filename := filepath.Join(t.TempDir(), "tests.json")
+	file, err := os.Create(filename)
+	require.NoError(t, err)
+	require.NoError(t, (&generator.JSONRenderer{}).Render(file, result))
+	require.NoError(t, file.Close())
+
+	loaded, err := LoadInventory(filename, "", nil)
+	require.NoError(t, err)
+	assert.True(t, Diff(result, loaded, diff.Options{}).Empty())
+
+	_, err = LoadInventory(filepath.Join(dir, "sample_test.go"), "", nil)
+	assert.Error(t, err)
+}
+
+func TestLoadInventory_GitRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git не установлен")
+	}
+
+	repo := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	dir := filepath.Join(repo, "sample")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	run("init", "-q")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sample_test.go"), []byte(diffTestFile), 0644))
+	run("add", "-A")
+	run("commit", "-q", "-m", "initial")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sample_test.go"), []byte(diffTestFileRenamed), 0644))
+
+	before, err := LoadInventory("HEAD", dir, nil)
+	require.NoError(t, err)
+	after, err := LoadInventory(dir, "", nil)
+	require.NoError(t, err)
+
+	report := Diff(before, after, diff.Options{})
+	assert.Equal(t, []diff.Change{
+		{Kind: diff.Skipped, Package: "sample", Name: "TestLegacy", New: "устарел"},
+		{Kind: diff.Renamed, Package: "sample", Name: "TestUserLogin", OldPackage: "sample", OldName: "TestLogin", Similarity: 1},
+		{Kind: diff.AnnotationsChanged, Package: "sample", Name: "TestUserLogin", Fields: []string{"description"}},
+	}, report.Changes)
+
+	_, err = LoadInventory("no-such-revision", dir, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,212 @@
+// Package badge рисует SVG бейджи в стиле shields.io без обращения к сети
+package badge
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/seblex/testdoc/pkg/generator"
+	"github.com/seblex/testdoc/pkg/results"
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// namedColors - цвета shields.io, которые можно указывать по имени
+var namedColors = map[string]string{
+	"brightgreen": "#4c1",
+	"green":       "#97ca00",
+	"yellowgreen": "#a4a61d",
+	"yellow":      "#dfb317",
+	"orange":      "#fe7d37",
+	"red":         "#e05d44",
+	"blue":        "#007ec6",
+	"lightgrey":   "#9f9f9f",
+	"grey":        "#555",
+}
+
+// Badge описывает один бейдж
+type Badge struct {
+	// Name - имя файла без расширения
+	Name    string
+	Label   string
+	Message string
+	Color   string
+}
+
+// svgTemplate - плоский бейдж shields.io. Параметры: ширина, подпись,
+// ширина подписи, ширина значения, цвет, центры текстов, значение.
+const svgTemplate = `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[2]s: %[7]s">
+<title>%[2]s: %[7]s</title>
+<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="%[3]d" height="20" fill="#555"/><rect x="%[3]d" width="%[4]d" height="20" fill="%[5]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="%[6]s" y="15" fill="#010101" fill-opacity=".3">%[2]s</text><text x="%[6]s" y="14">%[2]s</text>
+<text x="%[8]s" y="15" fill="#010101" fill-opacity=".3">%[7]s</text><text x="%[8]s" y="14">%[7]s</text>
+</g>
+</svg>
+`
+
+// SVG возвращает изображение бейджа
+func (b Badge) SVG() string {
+	labelWidth := textWidth(b.Label) + 10
+	messageWidth := textWidth(b.Message) + 10
+
+	return fmt.Sprintf(svgTemplate,
+		labelWidth+messageWidth,
+		html.EscapeString(b.Label),
+		labelWidth,
+		messageWidth,
+		html.EscapeString(Color(b.Color)),
+		formatCoord(float64(labelWidth)/2),
+		html.EscapeString(b.Message),
+		formatCoord(float64(labelWidth)+float64(messageWidth)/2))
+}
+
+// WriteTo записывает изображение бейджа в w
+func (b Badge) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, b.SVG())
+	return int64(n), err
+}
+
+// Color переводит имя цвета shields.io в шестнадцатеричный код.
+// Шестнадцатеричные коды без "#" дополняются; прочие значения
+// (цвета CSS) возвращаются без изменений.
+func Color(name string) string {
+	name = strings.TrimSpace(name)
+	if hex, ok := namedColors[strings.ToLower(name)]; ok {
+		return hex
+	}
+	if isHex(name) {
+		return "#" + name
+	}
+	return name
+}
+
+// isHex проверяет, является ли строка цветом в формате rgb или rrggbb без "#"
+func isHex(s string) bool {
+	if len(s) != 3 && len(s) != 6 {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.Is(unicode.ASCII_Hex_Digit, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// textWidth оценивает ширину текста в пикселях для шрифта Verdana 11px
+func textWidth(text string) int {
+	width := 0.0
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune("ijlI.,:;'!|", r):
+			width += 3.2
+		case r == ' ' || strings.ContainsRune("frt()[]", r):
+			width += 4.2
+		case strings.ContainsRune("mwMW%", r):
+			width += 10.5
+		case unicode.IsDigit(r):
+			width += 7
+		case unicode.IsUpper(r):
+			width += 7.6
+		default:
+			width += 6.6
+		}
+	}
+	return int(math.Ceil(width))
+}
+
+// formatCoord форматирует координату с точностью до половины пикселя
+func formatCoord(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%d", int(v))
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+// Inventory возвращает бейджи метрик набора тестов: общее количество,
+// пропущенные, покрытие аннотациями (Statistics.AnnotationCoverage) и
+// количество по типам. Бейдж доли прошедших тестов добавляется, только если
+// передан отчет о запуске.
+func Inventory(result *types.ParseResult, config *types.Config, report *results.Report) []Badge {
+	if config == nil {
+		config = types.DefaultConfig()
+	}
+	settings := config.Badges
+	stats := result.Stats
+
+	badges := []Badge{
+		{Name: "tests", Label: "тесты", Message: fmt.Sprintf("%d", stats.TotalTests), Color: settings.Color},
+		{
+			Name:    "skipped",
+			Label:   "пропущено",
+			Message: fmt.Sprintf("%d", stats.SkippedTests),
+			Color:   settings.Colors.Color(settings.Skipped.Lower(float64(stats.SkippedTests))),
+		},
+		{
+			Name:    "coverage",
+			Label:   "аннотации",
+			Message: formatPercent(stats.AnnotationCoverage()),
+			Color:   settings.Colors.Color(settings.Coverage.Higher(stats.AnnotationCoverage())),
+		},
+	}
+
+	g := generator.New(config)
+	for _, testType := range sortedTypes(stats.TypeDistribution) {
+		badges = append(badges, Badge{
+			Name:    "type-" + string(testType),
+			Label:   strings.ToLower(g.TestTypeDisplayName(testType)),
+			Message: fmt.Sprintf("%d", stats.TypeDistribution[testType]),
+			Color:   settings.Color,
+		})
+	}
+
+	if report != nil {
+		badges = append(badges, Badge{
+			Name:    "pass-rate",
+			Label:   "прошли",
+			Message: formatPercent(report.PassRate()),
+			Color:   settings.Colors.Color(settings.PassRate.Higher(report.PassRate())),
+		})
+	}
+
+	return badges
+}
+
+// Write записывает бейджи в директорию dir как <Name>.svg, создавая ее при необходимости
+func Write(dir string, badges []Badge) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, b := range badges {
+		if err := os.WriteFile(filepath.Join(dir, b.Name+".svg"), []byte(b.SVG()), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatPercent форматирует процент без лишних нулей: 100%, 87.5%
+func formatPercent(value float64) string {
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64) + "%"
+}
+
+// sortedTypes возвращает типы тестов из распределения по алфавиту
+func sortedTypes(distribution map[types.TestType]int) []types.TestType {
+	testTypes := make([]types.TestType, 0, len(distribution))
+	for testType := range distribution {
+		testTypes = append(testTypes, testType)
+	}
+	sort.Slice(testTypes, func(i, j int) bool { return testTypes[i] < testTypes[j] })
+	return testTypes
+}
@@ -0,0 +1,118 @@
+package badge
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seblex/testdoc/pkg/results"
+	"github.com/seblex/testdoc/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createBadgeResult() *types.ParseResult {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"auth": {Name: "auth", Tests: []types.TestInfo{
+				{Name: "TestLogin", Type: types.UnitTest, ExplicitType: true, Description: "Вход", Author: "Анна",
+					TestCases: []types.TestCase{{Name: "Вход", Steps: []types.Step{{Action: "Войти"}}}}},
+				{Name: "TestLogout", Type: types.UnitTest, ExplicitType: true, Description: "Выход", Tags: []string{"auth"}},
+				{Name: "TestSession", Type: types.IntegrationTest, ExplicitType: true, Description: "Сессия", Skipped: true},
+			}},
+		},
+	}
+	result.CalculateStats()
+	return result
+}
+
+func TestBadge_SVG(t *testing.T) {
+	b := Badge{Label: "тесты", Message: "42", Color: "blue"}
+	svg := b.SVG()
+
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg"`))
+	assert.Contains(t, svg, `aria-label="тесты: 42"`)
+	assert.Contains(t, svg, `fill="#007ec6"`)
+	assert.Contains(t, svg, `<text x="`)
+
+	// Изображение должно быть корректным XML, в том числе с экранированием
+	b = Badge{Label: `a<b & "c"`, Message: "1", Color: "ff0000"}
+	svg = b.SVG()
+	assert.Contains(t, svg, `fill="#ff0000"`)
+	assert.NoError(t, xml.Unmarshal([]byte(svg), new(struct{})))
+}
+
+func TestColor(t *testing.T) {
+	assert.Equal(t, "#4c1", Color("brightgreen"))
+	assert.Equal(t, "#e05d44", Color("Red"))
+	assert.Equal(t, "#abc", Color("abc"))
+	assert.Equal(t, "#123456", Color("#123456"))
+	assert.Equal(t, "purple", Color("purple"))
+}
+
+func TestTextWidth(t *testing.T) {
+	assert.Equal(t, 0, textWidth(""))
+	assert.Equal(t, 14, textWidth("42"))
+	assert.Greater(t, textWidth("WWW"), textWidth("iii"))
+}
+
+func TestInventory(t *testing.T) {
+	config := types.DefaultConfig()
+	badges := Inventory(createBadgeResult(), config, nil)
+
+	byName := make(map[string]Badge)
+	for _, b := range badges {
+		byName[b.Name] = b
+	}
+
+	require.Len(t, badges, 5)
+	assert.Equal(t, "3", byName["tests"].Message)
+	assert.Equal(t, "blue", byName["tests"].Color)
+	assert.Equal(t, "1", byName["skipped"].Message)
+	assert.Equal(t, "yellow", byName["skipped"].Color)
+	// Среднее покрытие критериев качества: (100 + 100 + 33.3 * 3) / 5
+	assert.Equal(t, "60%", byName["coverage"].Message)
+	assert.Equal(t, "yellow", byName["coverage"].Color)
+	assert.Equal(t, "модульные", byName["type-unit"].Label)
+	assert.Equal(t, "2", byName["type-unit"].Message)
+	assert.Equal(t, "1", byName["type-integration"].Message)
+	assert.NotContains(t, byName, "pass-rate")
+}
+
+func TestInventory_PassRate(t *testing.T) {
+	report, err := results.Parse(strings.NewReader(
+		`{"Action":"pass","Package":"p","Test":"TestA"}
+{"Action":"fail","Package":"p","Test":"TestB"}
+`))
+	require.NoError(t, err)
+
+	config := types.DefaultConfig()
+	config.Badges.Colors.Bad = "orange"
+	badges := Inventory(createBadgeResult(), config, report)
+
+	passRate := badges[len(badges)-1]
+	assert.Equal(t, "pass-rate", passRate.Name)
+	assert.Equal(t, "50%", passRate.Message)
+	assert.Equal(t, "orange", passRate.Color)
+}
+
+func TestWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "badges")
+	badges := Inventory(createBadgeResult(), nil, nil)
+
+	require.NoError(t, Write(dir, badges))
+
+	data, err := os.ReadFile(filepath.Join(dir, "tests.svg"))
+	require.NoError(t, err)
+	assert.Equal(t, badges[0].SVG(), string(data))
+	assert.FileExists(t, filepath.Join(dir, "type-unit.svg"))
+}
+
+func TestFormatPercent(t *testing.T) {
+	assert.Equal(t, "100%", formatPercent(100))
+	assert.Equal(t, "87.5%", formatPercent(87.5))
+	assert.Equal(t, "100%", formatPercent(99.99))
+	assert.Equal(t, "0%", formatPercent(0))
+}
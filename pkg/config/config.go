@@ -0,0 +1,357 @@
+// Package config собирает конфигурацию testdoc из нескольких слоев:
+// значения по умолчанию < пользовательский файл < файлы .testdoc.yaml
+// репозитория < переменные окружения TESTDOC_*. Флаги CLI применяются
+// поверх результата. Для каждого значения запоминается его источник.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// FileName - имя файла конфигурации репозитория и переопределений для поддеревьев
+const FileName = ".testdoc.yaml"
+
+// EnvPrefix - префикс переменных окружения с настройками
+const EnvPrefix = "TESTDOC_"
+
+// SourceDefault - источник значений по умолчанию
+const SourceDefault = "по умолчанию"
+
+// Options задает источники конфигурации
+type Options struct {
+	// Dir - директория, от которой вверх ищутся файлы .testdoc.yaml
+	Dir string
+	// File - явно указанный файл конфигурации. Заменяет поиск .testdoc.yaml.
+	File string
+	// UserFile - пользовательский файл; пустая строка означает
+	// <UserConfigDir>/testdoc/config.yaml, "-" отключает слой
+	UserFile string
+	// Environ - переменные окружения в формате KEY=VALUE; nil означает os.Environ()
+	Environ []string
+}
+
+// Effective содержит итоговую конфигурацию и источники ее значений
+type Effective struct {
+	Config *types.Config
+	// Files - прочитанные файлы конфигурации в порядке применения
+	Files []string
+	// origins сопоставляет путь ключа (badges.colors.good, exclude_patterns[0])
+	// с источником и позицией значения
+	origins map[string]origin
+}
+
+// origin - источник значения и его позиция в файле
+type origin struct {
+	source string
+	line   int
+	column int
+}
+
+// layer - один слой конфигурации
+type layer struct {
+	source string
+	node   *yaml.Node
+}
+
+// Load собирает конфигурацию из слоев, заданных opts
+func Load(opts Options) (*Effective, error) {
+	var files []string
+
+	userFile := opts.UserFile
+	if userFile == "" {
+		userFile = DefaultUserFile()
+	}
+	if userFile != "-" && userFile != "" {
+		if _, err := os.Stat(userFile); err == nil {
+			files = append(files, userFile)
+		}
+	}
+
+	if opts.File != "" {
+		files = append(files, opts.File)
+	} else {
+		found, err := Discover(opts.Dir)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, found...)
+	}
+
+	effective := &Effective{
+		Config:  types.DefaultConfig(),
+		Files:   files,
+		origins: make(map[string]origin),
+	}
+
+	for _, file := range files {
+		node, err := readFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := effective.apply(layer{source: file, node: node}); err != nil {
+			return nil, err
+		}
+	}
+
+	environ := opts.Environ
+	if environ == nil {
+		environ = os.Environ()
+	}
+	for _, l := range envLayers(environ) {
+		if err := effective.apply(l); err != nil {
+			return nil, err
+		}
+	}
+
+	return effective, nil
+}
+
+// LoadFile загружает файл конфигурации поверх значений по умолчанию
+func LoadFile(filename string) (*types.Config, error) {
+	node, err := readFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	config := types.DefaultConfig()
+	if err := decode(node, config, filename); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Overlay возвращает копию base с примененными значениями YAML документа data,
+// прочитанного из source. Ключи, отсутствующие в data, сохраняют значения base.
+func Overlay(base *types.Config, source string, data []byte) (*types.Config, error) {
+	config := Clone(base)
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	if len(doc.Content) == 0 {
+		return config, nil
+	}
+	if err := decode(doc.Content[0], config, source); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Clone возвращает копию конфигурации, не разделяющую с ней срезы и отображения
+func Clone(config *types.Config) *types.Config {
+	clone := *config
+	clone.LinkTemplates = cloneMap(config.LinkTemplates)
+	clone.CustomTemplates = cloneMap(config.CustomTemplates)
+	clone.Requirements = append([]string(nil), config.Requirements...)
+	clone.GroupBy = append([]string(nil), config.GroupBy...)
+	clone.ExcludePatterns = append([]string(nil), config.ExcludePatterns...)
+	clone.IncludePatterns = append([]string(nil), config.IncludePatterns...)
+	return &clone
+}
+
+// DefaultUserFile возвращает путь пользовательского файла конфигурации
+func DefaultUserFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "testdoc", "config.yaml")
+}
+
+// Discover ищет файлы .testdoc.yaml от директории dir вверх до корня
+// репозитория (директории с .git) или файловой системы. Возвращает файлы
+// от внешнего к внутреннему: ближайший к dir применяется последним.
+func Discover(dir string) ([]string, error) {
+	if dir == "" {
+		dir = "."
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	var files []string
+	for {
+		candidate := filepath.Join(dir, FileName)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			files = append([]string{candidate}, files...)
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return files, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return files, nil
+		}
+		dir = parent
+	}
+}
+
+// Source возвращает источник значения по пути ключа, например
+// "language" или "badges.colors.good"
+func (e *Effective) Source(key string) string {
+	if origin, ok := e.origins[key]; ok {
+		return origin.source
+	}
+	return SourceDefault
+}
+
+// Sources возвращает пути ключей, заданных не значениями по умолчанию
+func (e *Effective) Sources() map[string]string {
+	sources := make(map[string]string)
+	for key, origin := range e.origins {
+		if !strings.Contains(key, "[") {
+			sources[key] = origin.source
+		}
+	}
+	return sources
+}
+
+// apply применяет слой к конфигурации и запоминает источники его значений
+func (e *Effective) apply(l layer) error {
+	if l.node == nil {
+		return nil
+	}
+	if err := decode(l.node, e.Config, l.source); err != nil {
+		return err
+	}
+
+	leaves(l.node, "", func(key string, _, value *yaml.Node) {
+		e.origins[key] = origin{source: l.source, line: value.Line, column: value.Column}
+		if value.Kind == yaml.SequenceNode {
+			for i, item := range value.Content {
+				e.origins[fmt.Sprintf("%s[%d]", key, i)] = origin{source: l.source, line: item.Line, column: item.Column}
+			}
+		}
+	})
+	return nil
+}
+
+// readFile читает YAML файл и возвращает его корневой узел; nil для пустого файла
+func readFile(filename string) (*yaml.Node, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	return doc.Content[0], nil
+}
+
+// envLayers строит слои из переменных окружения TESTDOC_<КЛЮЧ>. Имя переменной -
+// путь ключа в верхнем регистре с "_" вместо ".": TESTDOC_LANGUAGE,
+// TESTDOC_BADGES_COLORS_GOOD. Списки задаются через запятую.
+func envLayers(environ []string) []layer {
+	values := make(map[string]string)
+	for _, entry := range environ {
+		name, value, ok := strings.Cut(entry, "=")
+		if ok && strings.HasPrefix(name, EnvPrefix) {
+			values[name] = value
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	var defaults yaml.Node
+	if err := defaults.Encode(types.DefaultConfig()); err != nil {
+		return nil
+	}
+
+	var layers []layer
+	leaves(&defaults, "", func(key string, _, node *yaml.Node) {
+		name := EnvName(key)
+		value, ok := values[name]
+		if !ok {
+			return
+		}
+
+		leaf := &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+		if node.Kind == yaml.SequenceNode {
+			leaf = &yaml.Node{Kind: yaml.SequenceNode}
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					leaf.Content = append(leaf.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: item})
+				}
+			}
+		}
+		layers = append(layers, layer{source: "$" + name, node: nest(key, leaf)})
+	})
+
+	sort.Slice(layers, func(i, j int) bool { return layers[i].source < layers[j].source })
+	return layers
+}
+
+// EnvName возвращает имя переменной окружения для пути ключа
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// nest оборачивает значение в отображения по пути ключа
+func nest(key string, value *yaml.Node) *yaml.Node {
+	parts := strings.Split(key, ".")
+	for i := len(parts) - 1; i >= 0; i-- {
+		value = &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: parts[i]},
+			value,
+		}}
+	}
+	return value
+}
+
+// leaves вызывает fn для каждого листового значения отображения: скаляра
+// или списка. Путь ключа составляется из имен через точку.
+func leaves(node *yaml.Node, prefix string, fn func(key string, keyNode, value *yaml.Node)) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		value := node.Content[i+1]
+		if value.Kind == yaml.MappingNode {
+			leaves(value, key, fn)
+			continue
+		}
+		fn(key, node.Content[i], value)
+	}
+}
+
+// cloneMap копирует отображение строк
+func cloneMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	clone := make(map[string]string, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// writeFile создает файл со всеми родительскими директориями
+func writeFile(t *testing.T, filename, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0755))
+	require.NoError(t, os.WriteFile(filename, []byte(content), 0644))
+}
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git"), 0755))
+	// Файл выше корня репозитория не учитывается
+	writeFile(t, filepath.Join(root, FileName), "title: outside\n")
+	writeFile(t, filepath.Join(repo, FileName), "title: repo\n")
+	writeFile(t, filepath.Join(repo, "services", "api", FileName), "title: api\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "services", "api", "handlers"), 0755))
+
+	files, err := Discover(filepath.Join(repo, "services", "api", "handlers"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(repo, FileName),
+		filepath.Join(repo, "services", "api", FileName),
+	}, files)
+
+	files, err = Discover(filepath.Join(root, "elsewhere"))
+	require.NoError(t, err)
+	assert.Contains(t, files, filepath.Join(root, FileName))
+}
+
+func TestLoad_Layers(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git"), 0755))
+
+	userFile := filepath.Join(root, "user.yaml")
+	writeFile(t, userFile, "author: Пользователь\nlanguage: en\nbadges:\n  colors:\n    good: green\n")
+	writeFile(t, filepath.Join(root, FileName), "title: Репозиторий\nlanguage: ru\nexclude_patterns: [\"legacy/**\"]\n")
+
+	effective, err := Load(Options{
+		Dir:      root,
+		UserFile: userFile,
+		Environ:  []string{"TESTDOC_TITLE=Из окружения", "TESTDOC_INCLUDE_SKIPPED=false", "TESTDOC_MIN_QUALITY=75", "OTHER=1"},
+	})
+	require.NoError(t, err)
+
+	config := effective.Config
+	assert.Equal(t, "Из окружения", config.Title)
+	assert.Equal(t, "Пользователь", config.Author)
+	assert.Equal(t, "ru", config.Language)
+	assert.Equal(t, []string{"legacy/**"}, config.ExcludePatterns)
+	assert.False(t, config.IncludeSkipped)
+	assert.Equal(t, 75.0, config.MinQuality)
+	assert.Equal(t, "green", config.Badges.Colors.Good)
+	// Незаданные ключи сохраняют значения по умолчанию
+	assert.Equal(t, "yellow", config.Badges.Colors.Warning)
+	assert.Equal(t, []string{"*_test.go"}, config.IncludePatterns)
+	assert.True(t, config.GroupByType)
+
+	assert.Equal(t, []string{userFile, filepath.Join(root, FileName)}, effective.Files)
+	assert.Equal(t, "$TESTDOC_TITLE", effective.Source("title"))
+	assert.Equal(t, userFile, effective.Source("author"))
+	assert.Equal(t, filepath.Join(root, FileName), effective.Source("language"))
+	assert.Equal(t, userFile, effective.Source("badges.colors.good"))
+	assert.Equal(t, SourceDefault, effective.Source("badges.colors.warning"))
+	assert.Len(t, effective.Sources(), 7)
+}
+
+func TestLoad_ExplicitFile(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git"), 0755))
+	writeFile(t, filepath.Join(root, FileName), "title: discovered\n")
+	explicit := filepath.Join(root, "ci.yaml")
+	writeFile(t, explicit, "version: 2.0.0\n")
+
+	effective, err := Load(Options{Dir: root, File: explicit, UserFile: "-", Environ: []string{}})
+	require.NoError(t, err)
+	assert.Equal(t, "Test Documentation", effective.Config.Title)
+	assert.Equal(t, "2.0.0", effective.Config.Version)
+	assert.Equal(t, []string{explicit}, effective.Files)
+
+	_, err = Load(Options{File: filepath.Join(root, "missing.yaml"), UserFile: "-", Environ: []string{}})
+	assert.Error(t, err)
+
+	writeFile(t, explicit, "title: [unclosed\n")
+	_, err = Load(Options{File: explicit, UserFile: "-", Environ: []string{}})
+	assert.ErrorContains(t, err, explicit)
+}
+
+func TestLoad_EnvLists(t *testing.T) {
+	effective, err := Load(Options{
+		Dir:      t.TempDir(),
+		UserFile: "-",
+		Environ:  []string{"TESTDOC_EXCLUDE_PATTERNS=vendor/**, *_bench_test.go,", "TESTDOC_BADGES_COVERAGE_GOOD=90"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vendor/**", "*_bench_test.go"}, effective.Config.ExcludePatterns)
+	assert.Equal(t, 90.0, effective.Config.Badges.Coverage.Good)
+	assert.Equal(t, 50.0, effective.Config.Badges.Coverage.Warning)
+
+	_, err = Load(Options{Dir: t.TempDir(), UserFile: "-", Environ: []string{"TESTDOC_MIN_QUALITY=много"}})
+	assert.ErrorContains(t, err, "$TESTDOC_MIN_QUALITY")
+}
+
+func TestEnvName(t *testing.T) {
+	assert.Equal(t, "TESTDOC_LANGUAGE", EnvName("language"))
+	assert.Equal(t, "TESTDOC_BADGES_PASS_RATE_GOOD", EnvName("badges.pass_rate.good"))
+}
+
+func TestLoadFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, filename, "title: Мой проект\n")
+
+	config, err := LoadFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, "Мой проект", config.Title)
+	assert.True(t, config.IncludeSkipped)
+	assert.Equal(t, []string{"*_test.go"}, config.IncludePatterns)
+	assert.Equal(t, types.DefaultBadgeConfig(), config.Badges)
+
+	writeFile(t, filename, "")
+	config, err = LoadFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, types.DefaultConfig(), config)
+}
+
+func TestOverlay(t *testing.T) {
+	base := types.DefaultConfig()
+	base.LinkTemplates["jira"] = "https://jira/{id}"
+
+	config, err := Overlay(base, "override.yaml", []byte("include_skipped: false\nexclude_patterns: [\"*_slow_test.go\"]\nlink_templates:\n  gh: https://github/{id}\n"))
+	require.NoError(t, err)
+	assert.False(t, config.IncludeSkipped)
+	assert.Equal(t, []string{"*_slow_test.go"}, config.ExcludePatterns)
+	assert.Equal(t, map[string]string{"jira": "https://jira/{id}", "gh": "https://github/{id}"}, config.LinkTemplates)
+
+	// Базовая конфигурация не меняется
+	assert.True(t, base.IncludeSkipped)
+	assert.Empty(t, base.ExcludePatterns)
+	assert.Len(t, base.LinkTemplates, 1)
+
+	_, err = Overlay(base, "override.yaml", []byte("include_skipped: [\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,13 @@
+package config
+
+import _ "embed"
+
+// schema - JSON Schema файла конфигурации для автодополнения в редакторах
+//
+//go:embed schema.json
+var schema []byte
+
+// Schema возвращает JSON Schema файла конфигурации
+func Schema() []byte {
+	return append([]byte(nil), schema...)
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/generator"
+	"github.com/seblex/testdoc/pkg/slug"
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// schemaNode - часть JSON Schema, используемая в тесте
+type schemaNode struct {
+	Ref                  string                 `json:"$ref"`
+	Enum                 []string               `json:"enum"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties interface{}            `json:"additionalProperties"`
+	Definitions          map[string]*schemaNode `json:"definitions"`
+}
+
+func TestSchema_CoversConfig(t *testing.T) {
+	var root schemaNode
+	require.NoError(t, json.Unmarshal(Schema(), &root))
+
+	var check func(node *schemaNode, typ reflect.Type, prefix string)
+	check = func(node *schemaNode, typ reflect.Type, prefix string) {
+		if node.Ref != "" {
+			node = root.Definitions[node.Ref[len("#/definitions/"):]]
+			require.NotNil(t, node, prefix)
+		}
+		assert.Equal(t, false, node.AdditionalProperties, "%s: неизвестные ключи должны быть запрещены", prefix)
+
+		fields := yamlFields(typ)
+		var want, got []string
+		for name := range fields {
+			want = append(want, name)
+		}
+		for name := range node.Properties {
+			got = append(got, name)
+		}
+		sort.Strings(want)
+		sort.Strings(got)
+		assert.Equal(t, want, got, "свойства %s", prefix)
+
+		for name, field := range fields {
+			if property, ok := node.Properties[name]; ok && field.Kind() == reflect.Struct {
+				check(property, field, prefix+name+".")
+			}
+		}
+	}
+	check(&root, reflect.TypeOf(types.Config{}), "")
+
+	assert.Equal(t, append([]string{""}, generator.Languages()...), root.Properties["language"].Enum)
+	styles := []string{""}
+	for _, style := range slug.Styles() {
+		styles = append(styles, string(style))
+	}
+	assert.Equal(t, styles, root.Properties["anchor_style"].Enum)
+	assert.Equal(t, append([]string{""}, generator.SortKeys()...), root.Properties["sort_by"].Enum)
+	assert.Equal(t, append([]string{""}, generator.SortOrders()...), root.Properties["sort_order"].Enum)
+}
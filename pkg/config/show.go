@@ -0,0 +1,31 @@
+package config
+
+import (
+	"io"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Write записывает итоговую конфигурацию в YAML, указывая источник
+// каждого значения в комментарии
+func (e *Effective) Write(w io.Writer) error {
+	var doc yaml.Node
+	if err := doc.Encode(e.Config); err != nil {
+		return err
+	}
+
+	leaves(&doc, "", func(key string, keyNode, value *yaml.Node) {
+		if value.Kind == yaml.ScalarNode {
+			value.LineComment = e.Source(key)
+		} else {
+			keyNode.LineComment = e.Source(key)
+		}
+	})
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func TestEffective_Write(t *testing.T) {
+	effective, err := Load(Options{
+		Dir:      t.TempDir(),
+		UserFile: "-",
+		Environ:  []string{"TESTDOC_LANGUAGE=en", "TESTDOC_INCLUDE_PATTERNS=*_test.go,*_spec.go"},
+	})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, effective.Write(&buf))
+
+	output := buf.String()
+	assert.Contains(t, output, "language: en # $TESTDOC_LANGUAGE\n")
+	assert.Contains(t, output, "title: Test Documentation # по умолчанию\n")
+	assert.Contains(t, output, "include_patterns: # $TESTDOC_INCLUDE_PATTERNS\n")
+	assert.Contains(t, output, "    good: brightgreen # по умолчанию\n")
+
+	// Вывод - корректная конфигурация
+	var decoded types.Config
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "en", decoded.Language)
+	assert.Equal(t, effective.Config.IncludePatterns, decoded.IncludePatterns)
+	assert.Equal(t, effective.Config.Badges, decoded.Badges)
+}
@@ -0,0 +1,360 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/seblex/testdoc/pkg/generator"
+	"github.com/seblex/testdoc/pkg/site"
+	"github.com/seblex/testdoc/pkg/slug"
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// FieldError описывает ошибку в значении конфигурации
+type FieldError struct {
+	// Key - путь ключа, например "badges.colors.good" или "exclude_patterns[1]"
+	Key string
+	// Source - файл или переменная окружения, задавшие значение
+	Source string
+	// Line и Column - позиция значения в файле, 0 если неизвестна
+	Line   int
+	Column int
+	// Message - описание ошибки
+	Message string
+}
+
+// Error форматирует ошибку как "файл:строка:столбец: ключ: сообщение"
+func (e *FieldError) Error() string {
+	location := e.Source
+	if e.Line > 0 {
+		location += fmt.Sprintf(":%d", e.Line)
+		if e.Column > 0 {
+			location += fmt.Sprintf(":%d", e.Column)
+		}
+	}
+
+	var parts []string
+	for _, part := range []string{location, e.Key, e.Message} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ": ")
+}
+
+// Errors - список ошибок конфигурации
+type Errors []*FieldError
+
+// Error перечисляет ошибки, по одной на строку
+func (e Errors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate проверяет значения конфигурации: языки, форматы, режимы, стили,
+// ключи группировки и сортировки из списков допустимых, диапазоны числовых параметров и синтаксис паттернов.
+// Пустые строки допустимы и означают значения по умолчанию.
+func Validate(config *types.Config) error {
+	var errs Errors
+	add := func(key, format string, args ...interface{}) {
+		errs = append(errs, &FieldError{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if config.Language != "" {
+		if message := oneOf(config.Language, generator.Languages()); message != "" {
+			add("language", "неизвестный язык %s", message)
+		}
+	}
+
+	if config.Format != "" {
+		if _, ok := generator.Lookup(config.Format); !ok && config.Format != site.Format {
+			add("format", "неизвестный формат вывода %s", oneOf(config.Format, append(generator.Formats(), site.Format)))
+		}
+	}
+
+	if config.SiteMode != "" {
+		if message := oneOf(config.SiteMode, []string{site.ModeHTML, site.ModeMarkdown}); message != "" {
+			add("site_mode", "неизвестный режим сайта %s", message)
+		}
+	}
+
+	if _, err := slug.ParseStyle(config.AnchorStyle); err != nil {
+		add("anchor_style", "%v", err)
+	}
+
+	if config.MinQuality < 0 || config.MinQuality > 100 {
+		add("min_quality", "должен быть в диапазоне 0..100, получено %g", config.MinQuality)
+	}
+
+	for _, threshold := range []struct {
+		key   string
+		value float64
+	}{
+		{"badges.coverage.good", config.Badges.Coverage.Good},
+		{"badges.coverage.warning", config.Badges.Coverage.Warning},
+		{"badges.pass_rate.good", config.Badges.PassRate.Good},
+		{"badges.pass_rate.warning", config.Badges.PassRate.Warning},
+	} {
+		if threshold.value < 0 || threshold.value > 100 {
+			add(threshold.key, "должен быть в диапазоне 0..100, получено %g", threshold.value)
+		}
+	}
+
+	if config.SortBy != "" {
+		if message := oneOf(config.SortBy, generator.SortKeys()); message != "" {
+			add("sort_by", "неизвестный ключ сортировки %s", message)
+		}
+	}
+
+	if config.SortOrder != "" {
+		if message := oneOf(config.SortOrder, generator.SortOrders()); message != "" {
+			add("sort_order", "неизвестное направление сортировки %s", message)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, key := range config.GroupBy {
+		field := fmt.Sprintf("group_by[%d]", i)
+		switch {
+		case !generator.ValidGroupKey(key):
+			keys := generator.GroupKeys()
+			add(field, "неизвестный ключ группировки %q (доступны: %s, %s<ключ>)%s",
+				key, strings.Join(keys, ", "), generator.GroupMetadataPrefix, suggest(key, keys))
+		case seen[key]:
+			add(field, "ключ группировки %q указан повторно", key)
+		}
+		seen[key] = true
+	}
+
+	for _, list := range []struct {
+		key      string
+		patterns []string
+	}{
+		{"include_patterns", config.IncludePatterns},
+		{"exclude_patterns", config.ExcludePatterns},
+	} {
+		for i, pattern := range list.patterns {
+			if err := checkPattern(pattern); err != nil {
+				add(fmt.Sprintf("%s[%d]", list.key, i), "%v", err)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Validate проверяет итоговую конфигурацию и указывает в ошибках файл,
+// строку и столбец, где задано неверное значение
+func (e *Effective) Validate() error {
+	err := Validate(e.Config)
+	errs, ok := err.(Errors)
+	if !ok {
+		return err
+	}
+
+	for _, fieldErr := range errs {
+		origin, ok := e.origins[fieldErr.Key]
+		if !ok {
+			// Для элемента списка без позиции используется позиция списка
+			base, _, _ := strings.Cut(fieldErr.Key, "[")
+			origin, ok = e.origins[base]
+		}
+		if ok {
+			fieldErr.Source, fieldErr.Line, fieldErr.Column = origin.source, origin.line, origin.column
+		}
+	}
+	return errs
+}
+
+// checkPattern проверяет синтаксис glob паттерна. Сегмент "**" означает
+// любое количество директорий, остальные сегменты проверяет path.Match.
+func checkPattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return fmt.Errorf("пустой паттерн")
+	}
+	for _, segment := range strings.Split(strings.TrimPrefix(pattern, "/"), "/") {
+		if segment == "**" {
+			continue
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("некорректный паттерн %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// oneOf возвращает пустую строку, если value входит в allowed, иначе
+// описание значения со списком допустимых и ближайшим вариантом
+func oneOf(value string, allowed []string) string {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return ""
+		}
+	}
+
+	return fmt.Sprintf("%q (доступны: %s)%s", value, strings.Join(allowed, ", "), suggest(value, allowed))
+}
+
+// suggest возвращает подсказку с ближайшим к value вариантом или пустую строку
+func suggest(value string, candidates []string) string {
+	if suggestion := closest(value, candidates); suggestion != "" {
+		return fmt.Sprintf("; возможно, имелось в виду %q", suggestion)
+	}
+	return ""
+}
+
+// checkFields проверяет, что все ключи отображения node известны структуре t.
+// Ключи сравниваются с тегами yaml полей; вложенные структуры проверяются
+// рекурсивно, отображения (link_templates) допускают любые ключи.
+func checkFields(node *yaml.Node, t reflect.Type, prefix, source string) Errors {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	fields := yamlFields(t)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs Errors
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode := node.Content[i]
+		key := keyNode.Value
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		field, ok := fields[keyNode.Value]
+		if !ok {
+			message := "неизвестный параметр"
+			if suggestion := closest(keyNode.Value, names); suggestion != "" {
+				message += fmt.Sprintf("; возможно, имелся в виду %q", suggestion)
+			}
+			errs = append(errs, &FieldError{
+				Key:     key,
+				Source:  source,
+				Line:    keyNode.Line,
+				Column:  keyNode.Column,
+				Message: message,
+			})
+			continue
+		}
+
+		if field.Kind() == reflect.Struct {
+			errs = append(errs, checkFields(node.Content[i+1], field, key, source)...)
+		}
+	}
+	return errs
+}
+
+// yamlFields возвращает типы полей структуры по именам из тегов yaml
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// decode строго декодирует узел в конфигурацию: неизвестные ключи и значения
+// неподходящего типа возвращаются как Errors с позициями в source
+func decode(node *yaml.Node, config *types.Config, source string) error {
+	if node == nil {
+		return nil
+	}
+	if errs := checkFields(node, reflect.TypeOf(*config), "", source); len(errs) > 0 {
+		return errs
+	}
+
+	err := node.Decode(config)
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		errs := make(Errors, 0, len(typeErr.Errors))
+		for _, message := range typeErr.Errors {
+			errs = append(errs, typeError(message, source))
+		}
+		return errs
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	return nil
+}
+
+// typeError преобразует сообщение yaml вида "line 3: cannot unmarshal ..." в FieldError
+func typeError(message, source string) *FieldError {
+	fieldErr := &FieldError{Source: source, Message: message}
+	if rest, ok := strings.CutPrefix(message, "line "); ok {
+		if number, text, ok := strings.Cut(rest, ": "); ok {
+			if line, err := strconv.Atoi(number); err == nil {
+				fieldErr.Line, fieldErr.Message = line, text
+			}
+		}
+	}
+	return fieldErr
+}
+
+// closest возвращает ближайший к value вариант, если он отличается не более
+// чем на треть длины (минимум на два символа), иначе пустую строку
+func closest(value string, candidates []string) string {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		distance := levenshtein(value, candidate)
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	limit := len([]rune(value)) / 3
+	if limit < 2 {
+		limit = 2
+	}
+	if bestDistance < 0 || bestDistance > limit {
+		return ""
+	}
+	return best
+}
+
+// levenshtein возвращает редакционное расстояние между строками
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current := make([]int, len(rb)+1)
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(prev[j]+1, current[j-1]+1, prev[j-1]+cost)
+		}
+		prev = current
+	}
+	return prev[len(rb)]
+}
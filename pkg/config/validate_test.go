@@ -0,0 +1,106 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Validate(types.DefaultConfig()))
+	require.NoError(t, Validate(&types.Config{}), "пустые значения означают значения по умолчанию")
+
+	config := types.DefaultConfig()
+	config.Language = "eng"
+	config.Format = "markdwn"
+	config.SiteMode = "pdf"
+	config.AnchorStyle = "bitbucket"
+	config.MinQuality = 120
+	config.Badges.PassRate.Good = -1
+	config.SortBy = "line"
+	config.SortOrder = "descending"
+	config.GroupBy = []string{"pakage", "type", "type", "metadata:", "metadata:component"}
+	config.IncludePatterns = []string{"*_test.go", "[a-"}
+	config.ExcludePatterns = []string{"**/testdata/**", ""}
+
+	err := Validate(config)
+	var errs Errors
+	require.ErrorAs(t, err, &errs)
+
+	keys := make([]string, len(errs))
+	for i, fieldErr := range errs {
+		keys[i] = fieldErr.Key
+	}
+	assert.Equal(t, []string{
+		"language", "format", "site_mode", "anchor_style", "min_quality",
+		"badges.pass_rate.good", "sort_by", "sort_order", "group_by[0]", "group_by[2]", "group_by[3]",
+		"include_patterns[1]", "exclude_patterns[1]",
+	}, keys)
+
+	assert.Equal(t, `language: неизвестный язык "eng" (доступны: en, english, ru, russian); возможно, имелось в виду "en"`, errs[0].Error())
+	assert.Contains(t, errs[1].Message, `возможно, имелось в виду "markdown"`)
+	assert.Equal(t, `неизвестный ключ группировки "pakage" (доступны: package, type, owner, tag, author, priority, severity, metadata:<ключ>); возможно, имелось в виду "package"`, errs[8].Message)
+	assert.Equal(t, `ключ группировки "type" указан повторно`, errs[9].Message)
+	assert.Contains(t, errs[11].Message, `некорректный паттерн "[a-"`)
+}
+
+func TestEffective_Validate(t *testing.T) {
+	root := t.TempDir()
+	filename := filepath.Join(root, FileName)
+	writeFile(t, filename, "title: Проект\nlanguage: eng\nexclude_patterns:\n  - vendor/**\n  - \"[\"\n")
+
+	effective, err := Load(Options{Dir: root, UserFile: "-", Environ: []string{"TESTDOC_MIN_QUALITY=200"}})
+	require.NoError(t, err)
+
+	err = effective.Validate()
+	require.Error(t, err)
+	assert.Equal(t, filename+`:2:11: language: неизвестный язык "eng" (доступны: en, english, ru, russian); возможно, имелось в виду "en"
+$TESTDOC_MIN_QUALITY: min_quality: должен быть в диапазоне 0..100, получено 200
+`+filename+`:5:5: exclude_patterns[1]: некорректный паттерн "[": syntax error in pattern`, err.Error())
+}
+
+func TestLoad_UnknownFields(t *testing.T) {
+	root := t.TempDir()
+	filename := filepath.Join(root, FileName)
+	writeFile(t, filename, "title: Проект\ngroup_by_packages: true\nbadges:\n  colours:\n    good: green\nlink_templates:\n  anything: https://example.com/{id}\n")
+
+	_, err := Load(Options{Dir: root, UserFile: "-", Environ: []string{}})
+	var errs Errors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.Equal(t, filename+`:2:1: group_by_packages: неизвестный параметр; возможно, имелся в виду "group_by_package"`, errs[0].Error())
+	assert.Equal(t, filename+`:4:3: badges.colours: неизвестный параметр; возможно, имелся в виду "colors"`, errs[1].Error())
+
+	// Подсказка не предлагается для непохожих ключей
+	writeFile(t, filename, "something_else: 1\n")
+	_, err = LoadFile(filename)
+	assert.EqualError(t, err, filename+":1:1: something_else: неизвестный параметр")
+
+	_, err = Overlay(types.DefaultConfig(), "sub/.testdoc.yaml", []byte("include_skiped: false\n"))
+	assert.EqualError(t, err, `sub/.testdoc.yaml:1:1: include_skiped: неизвестный параметр; возможно, имелся в виду "include_skipped"`)
+}
+
+func TestLoad_TypeErrors(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, filename, "title: Проект\nmin_quality: много\n")
+
+	_, err := LoadFile(filename)
+	var errs Errors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 1)
+	assert.Equal(t, filename, errs[0].Source)
+	assert.Equal(t, 2, errs[0].Line)
+	assert.Contains(t, errs[0].Message, "cannot unmarshal")
+}
+
+func TestClosest(t *testing.T) {
+	candidates := []string{"language", "format", "group_by_type"}
+	assert.Equal(t, "language", closest("langauge", candidates))
+	assert.Equal(t, "format", closest("fromat", candidates))
+	assert.Equal(t, "", closest("completely_different", candidates))
+	assert.Equal(t, "", closest("x", nil))
+}
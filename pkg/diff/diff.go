@@ -0,0 +1,304 @@
+// Package diff сравнивает две инвентаризации тестов и формирует журнал изменений
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// ChangeKind определяет вид изменения теста
+type ChangeKind string
+
+const (
+	// Added - тест добавлен
+	Added ChangeKind = "added"
+	// Removed - тест удален
+	Removed ChangeKind = "removed"
+	// Renamed - тест переименован или перенесен: тело почти не изменилось
+	Renamed ChangeKind = "renamed"
+	// Skipped - тест стал пропускаться
+	Skipped ChangeKind = "skipped"
+	// Unskipped - тест перестал пропускаться
+	Unskipped ChangeKind = "unskipped"
+	// TypeChanged - изменился тип теста
+	TypeChanged ChangeKind = "type_changed"
+	// AnnotationsChanged - изменились описание или аннотации
+	AnnotationsChanged ChangeKind = "annotations_changed"
+)
+
+// changeKinds перечисляет виды изменений в порядке вывода: сначала то,
+// что легче всего пропустить при ревью
+var changeKinds = []ChangeKind{Skipped, Removed, Unskipped, Added, Renamed, TypeChanged, AnnotationsChanged}
+
+// DefaultRenameThreshold - минимальное сходство тел для признания теста переименованным
+const DefaultRenameThreshold = 0.8
+
+// Options содержит параметры сравнения
+type Options struct {
+	// RenameThreshold - минимальное сходство тел от 0 до 1; 0 означает DefaultRenameThreshold
+	RenameThreshold float64
+}
+
+// Change описывает одно изменение теста
+type Change struct {
+	Kind    ChangeKind `json:"kind"`
+	Package string     `json:"package"`
+	Name    string     `json:"name"`
+	// OldPackage и OldName - прежнее расположение переименованного теста
+	OldPackage string `json:"old_package,omitempty"`
+	OldName    string `json:"old_name,omitempty"`
+	// Similarity - сходство тел переименованного теста
+	Similarity float64 `json:"similarity,omitempty"`
+	// Old и New - прежнее и новое значение: тип теста или причина пропуска
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+	// Fields - измененные аннотации
+	Fields []string `json:"fields,omitempty"`
+}
+
+// Report содержит результат сравнения
+type Report struct {
+	Changes []Change           `json:"changes"`
+	Counts  map[ChangeKind]int `json:"counts"`
+	Before  int                `json:"before"`
+	After   int                `json:"after"`
+}
+
+// Empty возвращает true, если изменений нет
+func (r *Report) Empty() bool {
+	return len(r.Changes) == 0
+}
+
+// ByKind возвращает изменения одного вида
+func (r *Report) ByKind(kind ChangeKind) []Change {
+	var changes []Change
+	for _, change := range r.Changes {
+		if change.Kind == kind {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// testKey идентифицирует тест в инвентаризации
+type testKey struct {
+	pkg  string
+	name string
+}
+
+// Compare сравнивает прежнюю и новую инвентаризации
+func Compare(before, after *types.ParseResult, opts Options) *Report {
+	threshold := opts.RenameThreshold
+	if threshold <= 0 {
+		threshold = DefaultRenameThreshold
+	}
+
+	oldTests := index(before)
+	newTests := index(after)
+	report := &Report{
+		Counts: make(map[ChangeKind]int),
+		Before: len(oldTests),
+		After:  len(newTests),
+	}
+
+	var removed, added []testKey
+	for _, key := range sortedKeys(oldTests) {
+		if newTest, ok := newTests[key]; ok {
+			report.compareTest(oldTests[key], newTest)
+		} else {
+			removed = append(removed, key)
+		}
+	}
+	for _, key := range sortedKeys(newTests) {
+		if _, ok := oldTests[key]; !ok {
+			added = append(added, key)
+		}
+	}
+
+	renamedFrom, renamedTo := matchRenames(removed, added, oldTests, newTests, threshold)
+	for _, key := range added {
+		if oldKey, ok := renamedTo[key]; ok {
+			oldTest, newTest := oldTests[oldKey], newTests[key]
+			report.add(Change{
+				Kind:       Renamed,
+				Package:    key.pkg,
+				Name:       key.name,
+				OldPackage: oldKey.pkg,
+				OldName:    oldKey.name,
+				Similarity: oldTest.Fingerprint.Similarity(newTest.Fingerprint),
+			})
+			report.compareTest(oldTest, newTest)
+			continue
+		}
+		report.add(Change{Kind: Added, Package: key.pkg, Name: key.name})
+	}
+	for _, key := range removed {
+		if !renamedFrom[key] {
+			report.add(Change{Kind: Removed, Package: key.pkg, Name: key.name})
+		}
+	}
+
+	report.sort()
+	return report
+}
+
+// compareTest добавляет изменения между двумя версиями одного теста
+func (r *Report) compareTest(oldTest, newTest types.TestInfo) {
+	change := Change{Package: newTest.Package, Name: newTest.Name}
+
+	switch {
+	case !oldTest.Skipped && newTest.Skipped:
+		change.Kind, change.New = Skipped, newTest.SkipReason
+		r.add(change)
+	case oldTest.Skipped && !newTest.Skipped:
+		change.Kind, change.Old = Unskipped, oldTest.SkipReason
+		r.add(change)
+	}
+
+	if oldTest.Type != newTest.Type {
+		change.Kind, change.Old, change.New = TypeChanged, string(oldTest.Type), string(newTest.Type)
+		r.add(change)
+	}
+
+	if fields := annotationChanges(oldTest, newTest); len(fields) > 0 {
+		r.add(Change{Kind: AnnotationsChanged, Package: newTest.Package, Name: newTest.Name, Fields: fields})
+	}
+}
+
+// annotationChanges возвращает имена измененных аннотаций
+func annotationChanges(oldTest, newTest types.TestInfo) []string {
+	var fields []string
+	check := func(name string, a, b interface{}) {
+		if !reflect.DeepEqual(a, b) {
+			fields = append(fields, name)
+		}
+	}
+
+	check("description", oldTest.Description, newTest.Description)
+	check("author", oldTest.Author, newTest.Author)
+	check("tags", nonEmpty(oldTest.Tags), nonEmpty(newTest.Tags))
+	check("owners", nonEmpty(oldTest.Owners), nonEmpty(newTest.Owners))
+	check("requirements", nonEmpty(oldTest.Requirements), nonEmpty(newTest.Requirements))
+	check("issues", nonEmpty(oldTest.Issues), nonEmpty(newTest.Issues))
+	check("stories", nonEmpty(oldTest.Stories), nonEmpty(newTest.Stories))
+	if len(oldTest.TestCases) > 0 || len(newTest.TestCases) > 0 {
+		// Сравнение через JSON не различает nil и пустые срезы внутри тест-кейсов
+		check("test_cases", jsonString(oldTest.TestCases), jsonString(newTest.TestCases))
+	}
+	if len(oldTest.Metadata) > 0 || len(newTest.Metadata) > 0 {
+		check("metadata", oldTest.Metadata, newTest.Metadata)
+	}
+
+	return fields
+}
+
+// jsonString возвращает JSON представление значения
+func jsonString(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// nonEmpty приводит пустой срез к nil, чтобы nil и [] считались равными
+func nonEmpty(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// matchRenames сопоставляет удаленные и добавленные тесты по сходству тел.
+// Пары выбираются жадно по убыванию сходства, каждый тест участвует не более чем в одной паре.
+func matchRenames(removed, added []testKey, oldTests, newTests map[testKey]types.TestInfo, threshold float64) (map[testKey]bool, map[testKey]testKey) {
+	type candidate struct {
+		from, to   testKey
+		similarity float64
+	}
+
+	var candidates []candidate
+	for _, from := range removed {
+		for _, to := range added {
+			similarity := oldTests[from].Fingerprint.Similarity(newTests[to].Fingerprint)
+			if similarity >= threshold {
+				candidates = append(candidates, candidate{from, to, similarity})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].similarity > candidates[j].similarity
+	})
+
+	renamedFrom := make(map[testKey]bool)
+	renamedTo := make(map[testKey]testKey)
+	for _, c := range candidates {
+		if renamedFrom[c.from] {
+			continue
+		}
+		if _, ok := renamedTo[c.to]; ok {
+			continue
+		}
+		renamedFrom[c.from] = true
+		renamedTo[c.to] = c.from
+	}
+	return renamedFrom, renamedTo
+}
+
+// add добавляет изменение в отчет
+func (r *Report) add(change Change) {
+	r.Changes = append(r.Changes, change)
+	r.Counts[change.Kind]++
+}
+
+// sort упорядочивает изменения по виду, пакету и имени теста
+func (r *Report) sort() {
+	order := make(map[ChangeKind]int, len(changeKinds))
+	for i, kind := range changeKinds {
+		order[kind] = i
+	}
+
+	sort.SliceStable(r.Changes, func(i, j int) bool {
+		a, b := r.Changes[i], r.Changes[j]
+		if a.Kind != b.Kind {
+			return order[a.Kind] < order[b.Kind]
+		}
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		return a.Name < b.Name
+	})
+}
+
+// index строит отображение тестов инвентаризации по пакету и имени
+func index(result *types.ParseResult) map[testKey]types.TestInfo {
+	tests := make(map[testKey]types.TestInfo)
+	if result == nil {
+		return tests
+	}
+
+	for name, pkg := range result.Packages {
+		for _, test := range pkg.Tests {
+			if test.Package == "" {
+				test.Package = name
+			}
+			tests[testKey{pkg: test.Package, name: test.Name}] = test
+		}
+	}
+	return tests
+}
+
+// sortedKeys возвращает ключи тестов в порядке пакета и имени
+func sortedKeys(tests map[testKey]types.TestInfo) []testKey {
+	keys := make([]testKey, 0, len(tests))
+	for key := range tests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].pkg != keys[j].pkg {
+			return keys[i].pkg < keys[j].pkg
+		}
+		return keys[i].name < keys[j].name
+	})
+	return keys
+}
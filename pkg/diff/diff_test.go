@@ -0,0 +1,181 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/seblex/testdoc/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fingerprint(body string) types.Fingerprint {
+	return types.NewFingerprint(strings.Fields(body))
+}
+
+func inventory(tests ...types.TestInfo) *types.ParseResult {
+	result := &types.ParseResult{Packages: map[string]*types.PackageInfo{}}
+	for _, test := range tests {
+		pkg, ok := result.Packages[test.Package]
+		if !ok {
+			pkg = &types.PackageInfo{Name: test.Package}
+			result.Packages[test.Package] = pkg
+		}
+		pkg.Tests = append(pkg.Tests, test)
+	}
+	return result
+}
+
+const loginBody = `user := NewUser ( "alice" ) ; err := user . Login ( "secret" ) ; require . NoError ( t , err ) ; assert . True ( t , user . Active )`
+
+func TestCompare_AddedRemovedRenamed(t *testing.T) {
+	before := inventory(
+		types.TestInfo{Package: "auth", Name: "TestLogin", Type: types.UnitTest, Fingerprint: fingerprint(loginBody)},
+		types.TestInfo{Package: "auth", Name: "TestLegacy", Type: types.UnitTest, Fingerprint: fingerprint("legacy ( ) ; legacy2 ( ) ; legacy3 ( )")},
+	)
+	after := inventory(
+		types.TestInfo{Package: "auth", Name: "TestUserLogin", Type: types.UnitTest, Fingerprint: fingerprint(loginBody)},
+		types.TestInfo{Package: "auth", Name: "TestLogout", Type: types.UnitTest, Fingerprint: fingerprint("logout ( ) ; check ( ) ; done ( )")},
+	)
+
+	report := Compare(before, after, Options{})
+
+	assert.Equal(t, 2, report.Before)
+	assert.Equal(t, 2, report.After)
+	assert.Equal(t, []Change{
+		{Kind: Removed, Package: "auth", Name: "TestLegacy"},
+		{Kind: Added, Package: "auth", Name: "TestLogout"},
+		{Kind: Renamed, Package: "auth", Name: "TestUserLogin", OldPackage: "auth", OldName: "TestLogin", Similarity: 1},
+	}, report.Changes)
+	assert.Equal(t, 1, report.Counts[Renamed])
+}
+
+func TestCompare_RenameThreshold(t *testing.T) {
+	before := inventory(types.TestInfo{Package: "auth", Name: "TestA", Fingerprint: fingerprint(loginBody)})
+	after := inventory(types.TestInfo{Package: "auth", Name: "TestB",
+		Fingerprint: fingerprint(strings.Replace(loginBody, `"secret"`, `"other"`, 1))})
+
+	similarity := before.Packages["auth"].Tests[0].Fingerprint.Similarity(after.Packages["auth"].Tests[0].Fingerprint)
+	require.Less(t, similarity, 1.0)
+
+	report := Compare(before, after, Options{RenameThreshold: 1})
+	assert.Equal(t, 1, report.Counts[Added])
+	assert.Equal(t, 1, report.Counts[Removed])
+
+	report = Compare(before, after, Options{RenameThreshold: similarity})
+	assert.Equal(t, 1, report.Counts[Renamed])
+}
+
+func TestCompare_EmptyBodiesAreNotRenames(t *testing.T) {
+	before := inventory(types.TestInfo{Package: "auth", Name: "TestA"})
+	after := inventory(types.TestInfo{Package: "auth", Name: "TestB"})
+
+	report := Compare(before, after, Options{})
+	assert.Equal(t, 0, report.Counts[Renamed])
+	assert.Equal(t, 1, report.Counts[Added])
+	assert.Equal(t, 1, report.Counts[Removed])
+}
+
+func TestCompare_ChangedTests(t *testing.T) {
+	before := inventory(
+		types.TestInfo{Package: "api", Name: "TestHandler", Type: types.UnitTest, Description: "Старое"},
+		types.TestInfo{Package: "api", Name: "TestFlaky", Type: types.UnitTest, Skipped: true, SkipReason: "нестабилен"},
+		types.TestInfo{Package: "api", Name: "TestSlow", Type: types.UnitTest, Tags: []string{}},
+	)
+	after := inventory(
+		types.TestInfo{Package: "api", Name: "TestHandler", Type: types.IntegrationTest, Description: "Новое", Author: "bob"},
+		types.TestInfo{Package: "api", Name: "TestFlaky", Type: types.UnitTest},
+		types.TestInfo{Package: "api", Name: "TestSlow", Type: types.UnitTest, Skipped: true, SkipReason: "медленный"},
+	)
+
+	report := Compare(before, after, Options{})
+
+	assert.Equal(t, []Change{
+		{Kind: Skipped, Package: "api", Name: "TestSlow", New: "медленный"},
+		{Kind: Unskipped, Package: "api", Name: "TestFlaky", Old: "нестабилен"},
+		{Kind: TypeChanged, Package: "api", Name: "TestHandler", Old: "unit", New: "integration"},
+		{Kind: AnnotationsChanged, Package: "api", Name: "TestHandler", Fields: []string{"description", "author"}},
+	}, report.Changes)
+}
+
+func TestCompare_NoChanges(t *testing.T) {
+	result := inventory(types.TestInfo{Package: "api", Name: "TestHandler", Type: types.UnitTest})
+
+	report := Compare(result, result, Options{})
+	assert.True(t, report.Empty())
+	assert.Equal(t, 1, report.Before)
+
+	report = Compare(nil, result, Options{})
+	assert.Equal(t, 1, report.Counts[Added])
+}
+
+func createReport() *Report {
+	before := inventory(
+		types.TestInfo{Package: "auth", Name: "TestLogin", Fingerprint: fingerprint(loginBody)},
+		types.TestInfo{Package: "auth", Name: "TestSession", Type: types.UnitTest},
+	)
+	after := inventory(
+		types.TestInfo{Package: "auth", Name: "TestUserLogin", Fingerprint: fingerprint(loginBody)},
+		types.TestInfo{Package: "auth", Name: "TestSession", Type: types.UnitTest, Skipped: true, SkipReason: "ждет исправления"},
+		types.TestInfo{Package: "auth", Name: "TestNew"},
+	)
+	return Compare(before, after, Options{})
+}
+
+func TestReport_WriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, createReport().WriteMarkdown(&buf))
+
+	output := buf.String()
+	assert.True(t, strings.HasPrefix(output, "# Изменения тестов\n"))
+	assert.Contains(t, output, "**Было тестов:** 2, **стало:** 3")
+	assert.Contains(t, output, "## ⚠️ Стали пропускаться (1)")
+	assert.Contains(t, output, "- `auth.TestSession` — ждет исправления")
+	assert.Contains(t, output, "- `auth.TestLogin` → `auth.TestUserLogin` (сходство 100%)")
+	assert.Contains(t, output, "## ➕ Добавлены (1)")
+	assert.Less(t, strings.Index(output, "Стали пропускаться"), strings.Index(output, "Добавлены"))
+
+	buf.Reset()
+	require.NoError(t, (&Report{Counts: map[ChangeKind]int{}}).WriteMarkdown(&buf))
+	assert.Contains(t, buf.String(), "Изменений нет.")
+}
+
+func TestReport_WriteSummary(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, createReport().WriteSummary(&buf))
+
+	output := buf.String()
+	assert.Contains(t, output, "⚠️ стали пропускаться: 1 · ➕ добавлены: 1 · ✏️ переименованы: 1")
+	assert.Contains(t, output, "**Стали пропускаться:**\n- `auth.TestSession` — ждет исправления")
+	assert.NotContains(t, output, "TestNew")
+}
+
+func TestReport_WriteSummary_Limit(t *testing.T) {
+	var removed []types.TestInfo
+	for i := 0; i < SummaryLimit+2; i++ {
+		removed = append(removed, types.TestInfo{Package: "api", Name: fmt.Sprintf("Test%d", i)})
+	}
+	report := Compare(inventory(removed...), nil, Options{})
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteSummary(&buf))
+	assert.Equal(t, SummaryLimit+1, strings.Count(buf.String(), "\n- "))
+	assert.Contains(t, buf.String(), "- … и еще 2\n")
+}
+
+func TestReport_Write(t *testing.T) {
+	report := createReport()
+
+	var buf bytes.Buffer
+	require.NoError(t, report.Write(&buf, FormatJSON))
+
+	var decoded Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, report.Changes, decoded.Changes)
+	assert.Equal(t, 1, decoded.Counts[Skipped])
+
+	assert.Error(t, report.Write(&buf, "xml"))
+}
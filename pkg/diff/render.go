@@ -0,0 +1,174 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Форматы вывода отчета
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+	// FormatSummary - краткая сводка для комментария к pull request
+	FormatSummary = "summary"
+)
+
+// SummaryLimit - максимальное количество тестов в списке одного вида в сводке
+const SummaryLimit = 5
+
+// Write записывает отчет в w в формате format
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case "", FormatMarkdown:
+		return r.WriteMarkdown(w)
+	case FormatJSON:
+		return r.WriteJSON(w)
+	case FormatSummary:
+		return r.WriteSummary(w)
+	default:
+		return fmt.Errorf("неизвестный формат отчета %q (доступны: %s, %s, %s)",
+			format, FormatMarkdown, FormatJSON, FormatSummary)
+	}
+}
+
+// WriteJSON записывает отчет в формате JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteMarkdown записывает полный журнал изменений тестов в Markdown
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("# Изменения тестов\n\n")
+	sb.WriteString(fmt.Sprintf("**Было тестов:** %d, **стало:** %d\n\n", r.Before, r.After))
+
+	if r.Empty() {
+		sb.WriteString("Изменений нет.\n")
+		_, err := io.WriteString(w, sb.String())
+		return err
+	}
+
+	for _, kind := range changeKinds {
+		changes := r.ByKind(kind)
+		if len(changes) == 0 {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("## %s %s (%d)\n\n", kindIcon(kind), kindTitle(kind), len(changes)))
+		for _, change := range changes {
+			sb.WriteString("- " + formatChange(change) + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteSummary записывает краткую сводку для комментария к pull request:
+// строку счетчиков и списки пропущенных и удаленных тестов
+func (r *Report) WriteSummary(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("### 🧪 Изменения тестов\n\n")
+
+	if r.Empty() {
+		sb.WriteString("Изменений в тестах нет.\n")
+		_, err := io.WriteString(w, sb.String())
+		return err
+	}
+
+	var counts []string
+	for _, kind := range changeKinds {
+		if count := r.Counts[kind]; count > 0 {
+			counts = append(counts, fmt.Sprintf("%s %s: %d", kindIcon(kind), strings.ToLower(kindTitle(kind)), count))
+		}
+	}
+	sb.WriteString(strings.Join(counts, " · ") + "\n\n")
+
+	for _, kind := range []ChangeKind{Skipped, Removed} {
+		changes := r.ByKind(kind)
+		if len(changes) == 0 {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("**%s:**\n", kindTitle(kind)))
+		for i, change := range changes {
+			if i == SummaryLimit {
+				sb.WriteString(fmt.Sprintf("- … и еще %d\n", len(changes)-SummaryLimit))
+				break
+			}
+			sb.WriteString("- " + formatChange(change) + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// formatChange форматирует одно изменение для списка
+func formatChange(change Change) string {
+	name := fmt.Sprintf("`%s.%s`", change.Package, change.Name)
+
+	switch change.Kind {
+	case Renamed:
+		return fmt.Sprintf("`%s.%s` → %s (сходство %.0f%%)", change.OldPackage, change.OldName, name, change.Similarity*100)
+	case Skipped:
+		if change.New != "" {
+			return fmt.Sprintf("%s — %s", name, change.New)
+		}
+	case TypeChanged:
+		return fmt.Sprintf("%s: %s → %s", name, change.Old, change.New)
+	case AnnotationsChanged:
+		return fmt.Sprintf("%s: %s", name, strings.Join(change.Fields, ", "))
+	}
+	return name
+}
+
+// kindTitle возвращает заголовок раздела изменений
+func kindTitle(kind ChangeKind) string {
+	switch kind {
+	case Added:
+		return "Добавлены"
+	case Removed:
+		return "Удалены"
+	case Renamed:
+		return "Переименованы"
+	case Skipped:
+		return "Стали пропускаться"
+	case Unskipped:
+		return "Снова выполняются"
+	case TypeChanged:
+		return "Изменен тип"
+	case AnnotationsChanged:
+		return "Изменены аннотации"
+	default:
+		return string(kind)
+	}
+}
+
+// kindIcon возвращает значок вида изменений
+func kindIcon(kind ChangeKind) string {
+	switch kind {
+	case Added:
+		return "➕"
+	case Removed:
+		return "➖"
+	case Renamed:
+		return "✏️"
+	case Skipped:
+		return "⚠️"
+	case Unskipped:
+		return "▶️"
+	case TypeChanged:
+		return "🔀"
+	case AnnotationsChanged:
+		return "📝"
+	default:
+		return "•"
+	}
+}
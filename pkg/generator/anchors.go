@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/seblex/testdoc/pkg/slug"
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// anchorIndex хранит якоря заголовков групп и тестов в порядке их появления.
+// Оглавление обходит группы и тесты в том же порядке, что и основной
+// контент, поэтому повторяющиеся заголовки получают якоря с нужными суффиксами.
+type anchorIndex struct {
+	style slug.Style
+	slugs map[string][]string
+}
+
+// next возвращает якорь очередного заголовка уровня level с текстом text.
+// Если заголовок не встречался в документе, якорь вычисляется без учета повторов.
+func (a *anchorIndex) next(level int, text string) string {
+	if a == nil {
+		return slug.Make(slug.GitHub, text)
+	}
+
+	key := headingKey(level, text)
+	queue := a.slugs[key]
+	if len(queue) == 0 {
+		return slug.Make(a.style, text)
+	}
+
+	a.slugs[key] = queue[1:]
+	return queue[0]
+}
+
+// headingKey возвращает ключ заголовка в индексе якорей
+func headingKey(level int, text string) string {
+	return fmt.Sprintf("%d:%s", level, text)
+}
+
+// add добавляет якорь заголовка уровня level с текстом text. Якорь вычисляется
+// slugger с учетом предыдущих заголовков.
+func (a *anchorIndex) add(slugger *slug.Slugger, level int, text string) {
+	key := headingKey(level, text)
+	a.slugs[key] = append(a.slugs[key], slugger.Slug(text))
+}
+
+// collectAnchors вычисляет якоря заголовков групп и тестов, на которые ссылается
+// оглавление, обходя дерево секций в порядке документа
+func (g *Generator) collectAnchors(packages map[string]*types.PackageInfo) *anchorIndex {
+	style := g.anchorStyle()
+	index := &anchorIndex{style: style, slugs: make(map[string][]string)}
+	slugger := slug.New(style)
+
+	keys := g.groupKeys()
+	if len(keys) == 0 {
+		for _, test := range g.allTests(packages) {
+			index.add(slugger, 3, test.Name)
+		}
+		return index
+	}
+
+	var walk func(s *section, level int)
+	walk = func(s *section, level int) {
+		index.add(slugger, level, s.title)
+		if len(s.children) > 0 {
+			for _, child := range s.children {
+				walk(child, level+1)
+			}
+			return
+		}
+		for _, test := range s.tests {
+			index.add(slugger, level+1, test.Name)
+		}
+	}
+	for _, s := range g.buildSections(packages, keys) {
+		walk(s, 2)
+	}
+	return index
+}
+
+// anchorStyle возвращает алгоритм якорей из конфигурации
+func (g *Generator) anchorStyle() slug.Style {
+	style, err := slug.ParseStyle(g.config.AnchorStyle)
+	if err != nil {
+		return slug.GitHub
+	}
+	return style
+}
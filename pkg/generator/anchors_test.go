@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/seblex/testdoc/pkg/slug"
+	"github.com/seblex/testdoc/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tocLinkRe находит ссылки оглавления вида "- [текст](#якорь)"
+var tocLinkRe = regexp.MustCompile(`(?m)^\s*- \[[^\]]*\]\(#([^)]*)\)$`)
+
+// createDuplicateResult возвращает тесты с одинаковыми именами в разных пакетах
+func createDuplicateResult() *types.ParseResult {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"api/v1": {Name: "api/v1", Tests: []types.TestInfo{
+				{Name: "TestCreate_User", Type: types.UnitTest, Package: "api/v1", Owners: []string{"@org/api"}},
+				{Name: "TestDelete", Type: types.IntegrationTest, Package: "api/v1"},
+			}},
+			"api/v2": {Name: "api/v2", Tests: []types.TestInfo{
+				{Name: "TestCreate_User", Type: types.UnitTest, Package: "api/v2", Owners: []string{"@org/api"}},
+				{Name: "TestDelete", Type: types.UnitTest, Package: "api/v2"},
+			}},
+		},
+	}
+	result.CalculateStats()
+	return result
+}
+
+// headingAnchors возвращает якоря всех заголовков документа так, как их
+// вычисляет хостинг. Блоки кода пропускаются.
+func headingAnchors(style slug.Style, doc string) map[string]bool {
+	slugger := slug.New(style)
+	anchors := make(map[string]bool)
+
+	fence := ""
+	for _, line := range strings.Split(doc, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		if _, text, ok := parseHeading(line); ok {
+			anchors[slugger.Slug(text)] = true
+		}
+	}
+	return anchors
+}
+
+// parseHeading распознает ATX заголовок Markdown и возвращает его уровень и текст
+func parseHeading(line string) (int, string, bool) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return 0, "", false
+	}
+
+	rest := line[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return 0, "", false
+	}
+
+	// Закрывающие символы "#" не входят в текст заголовка
+	text := strings.TrimSpace(rest)
+	if trimmed := strings.TrimRight(text, "#"); trimmed != text && (trimmed == "" || strings.HasSuffix(trimmed, " ")) {
+		text = strings.TrimSpace(trimmed)
+	}
+	return level, text, true
+}
+
+func TestGenerator_TOCLinksResolve(t *testing.T) {
+	configs := map[string]*types.Config{
+		"simple":     {},
+		"by package": {GroupByPackage: true},
+		"by type":    {GroupByType: true},
+		"by owner":   {GroupByOwner: true},
+		"gitlab":     {GroupByPackage: true, AnchorStyle: "gitlab"},
+		"nested":     {GroupBy: []string{GroupOwner, GroupPackage, GroupType}},
+		"charts":     {GroupByPackage: true, Charts: true, Traceability: true},
+	}
+
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			doc := New(config).GenerateMarkdown(createDuplicateResult())
+			style, err := slug.ParseStyle(config.AnchorStyle)
+			require.NoError(t, err)
+
+			anchors := headingAnchors(style, doc)
+			links := tocLinkRe.FindAllStringSubmatch(doc, -1)
+			require.NotEmpty(t, links)
+
+			seen := make(map[string]bool)
+			for _, link := range links {
+				assert.True(t, anchors[link[1]], "ссылка #%s не найдена среди заголовков", link[1])
+				assert.False(t, seen[link[1]], "ссылка #%s повторяется", link[1])
+				seen[link[1]] = true
+			}
+		})
+	}
+}
+
+func TestGenerator_TOCDuplicateNames(t *testing.T) {
+	doc := New(&types.Config{GroupByPackage: true}).GenerateMarkdown(createDuplicateResult())
+
+	assert.Contains(t, doc, "- [Пакет api/v1](#пакет-apiv1)")
+	assert.Contains(t, doc, "- [Пакет api/v2](#пакет-apiv2)")
+	assert.Contains(t, doc, "  - [TestCreate_User](#testcreate_user)")
+	assert.Contains(t, doc, "  - [TestCreate_User](#testcreate_user-1)")
+	assert.Contains(t, doc, "  - [TestDelete](#testdelete-1)")
+	assert.True(t, strings.Index(doc, "(#testdelete)") < strings.Index(doc, "(#testdelete-1)"))
+}
+
+func TestParseHeading(t *testing.T) {
+	tests := []struct {
+		line  string
+		level int
+		text  string
+		ok    bool
+	}{
+		{"# Заголовок", 1, "Заголовок", true},
+		{"### TestA ###", 3, "TestA", true},
+		{"## C# тесты", 2, "C# тесты", true},
+		{"#hashtag", 0, "", false},
+		{"####### слишком глубоко", 0, "", false},
+		{"текст", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			level, text, ok := parseHeading(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.level, level)
+			assert.Equal(t, tt.text, text)
+		})
+	}
+}
+
+func TestHeadingAnchors_SkipsCodeBlocks(t *testing.T) {
+	anchors := headingAnchors(slug.GitHub, "# Title\n\n```yaml\n# comment\n```\n\n## Title\n")
+
+	assert.True(t, anchors["title"])
+	assert.True(t, anchors["title-1"])
+	assert.False(t, anchors["comment"])
+}
@@ -0,0 +1,262 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// maxEmbeddedTests - число тестов пакета в графе структуры, встроенном в документ.
+// Mermaid ограничивает число ребер графа, а полный граф остается в файлах
+// hierarchy.mmd и hierarchy.dot.
+const maxEmbeddedTests = 10
+
+// chartFile описывает отдельный файл диаграммы
+type chartFile struct {
+	name  string
+	write func(sb io.StringWriter)
+}
+
+// chartFiles возвращает диаграммы для записи в отдельные файлы: Mermaid (.mmd)
+// и граф иерархии в формате Graphviz (.dot)
+func (g *Generator) chartFiles(result *types.ParseResult) []chartFile {
+	return []chartFile{
+		{"types.mmd", func(sb io.StringWriter) { g.writeTypePie(sb, &result.Stats) }},
+		{"status.mmd", func(sb io.StringWriter) { g.writeStatusPie(sb, &result.Stats) }},
+		{"packages.mmd", func(sb io.StringWriter) { g.writePackageBars(sb, result.Packages) }},
+		{"hierarchy.mmd", func(sb io.StringWriter) { g.writeHierarchyMermaid(sb, result.Packages, 0) }},
+		{"hierarchy.dot", func(sb io.StringWriter) { g.writeHierarchyDOT(sb, result.Packages) }},
+	}
+}
+
+// GenerateCharts генерирует диаграммы распределения и структуры тестов.
+// Возвращает отображение имени файла в его содержимое.
+func (g *Generator) GenerateCharts(result *types.ParseResult) map[string]string {
+	charts := make(map[string]string)
+	for _, chart := range g.chartFiles(result) {
+		var sb strings.Builder
+		chart.write(&sb)
+		charts[chart.name] = sb.String()
+	}
+	return charts
+}
+
+// WriteCharts записывает диаграммы в директорию dir, создавая ее при необходимости
+func (g *Generator) WriteCharts(dir string, result *types.ParseResult) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, chart := range g.chartFiles(result) {
+		if err := g.writeChartFile(filepath.Join(dir, chart.name), chart.write); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeChartFile создает файл диаграммы
+func (g *Generator) writeChartFile(filename string, write func(sb io.StringWriter)) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	sb := newDocWriter(f)
+	write(sb)
+	if err := sb.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// generateCharts встраивает Mermaid диаграммы в документ. GitHub и GitLab
+// отображают блоки ```mermaid без дополнительных настроек.
+func (g *Generator) generateCharts(sb io.StringWriter, result *types.ParseResult) {
+	if result.Stats.TotalTests == 0 {
+		return
+	}
+
+	sb.WriteString("### Диаграммы\n\n")
+	for _, write := range []func(sb io.StringWriter){
+		func(sb io.StringWriter) { g.writeTypePie(sb, &result.Stats) },
+		func(sb io.StringWriter) { g.writeStatusPie(sb, &result.Stats) },
+		func(sb io.StringWriter) { g.writePackageBars(sb, result.Packages) },
+	} {
+		sb.WriteString("```mermaid\n")
+		write(sb)
+		sb.WriteString("```\n\n")
+	}
+
+	sb.WriteString("### Структура тестов\n\n")
+	sb.WriteString("```mermaid\n")
+	g.writeHierarchyMermaid(sb, result.Packages, maxEmbeddedTests)
+	sb.WriteString("```\n\n")
+}
+
+// writeTypePie записывает круговую диаграмму распределения тестов по типам
+func (g *Generator) writeTypePie(sb io.StringWriter, stats *types.Statistics) {
+	sb.WriteString("pie showData title Распределение по типам\n")
+	for _, testType := range sortedTypes(stats.TypeDistribution) {
+		sb.WriteString(fmt.Sprintf("    %s : %d\n",
+			mermaidString(g.getTestTypeDisplayName(testType)), stats.TypeDistribution[testType]))
+	}
+}
+
+// writeStatusPie записывает круговую диаграмму активных и пропущенных тестов
+func (g *Generator) writeStatusPie(sb io.StringWriter, stats *types.Statistics) {
+	sb.WriteString("pie showData title Статус тестов\n")
+	sb.WriteString(fmt.Sprintf("    %s : %d\n", mermaidString("Активные"), stats.ActiveTests))
+	sb.WriteString(fmt.Sprintf("    %s : %d\n", mermaidString("Пропущенные"), stats.SkippedTests))
+}
+
+// writePackageBars записывает столбчатую диаграмму тестов по пакетам с разбивкой
+// по типам. Mermaid не поддерживает столбцы с накоплением, поэтому серии
+// рисуются поверх друг друга с накопленными суммами: видимая часть каждой
+// серии равна числу тестов соответствующего типа.
+func (g *Generator) writePackageBars(sb io.StringWriter, packages map[string]*types.PackageInfo) {
+	packageNames := sortedPackageNames(packages)
+
+	distribution := make(map[types.TestType]int)
+	counts := make([]map[types.TestType]int, len(packageNames))
+	maxTotal := 0
+	for i, name := range packageNames {
+		counts[i] = make(map[types.TestType]int)
+		for _, test := range packages[name].Tests {
+			counts[i][test.Type]++
+			distribution[test.Type]++
+		}
+		if total := len(packages[name].Tests); total > maxTotal {
+			maxTotal = total
+		}
+	}
+	testTypes := sortedTypes(distribution)
+
+	layers := make([]string, len(testTypes))
+	for i, testType := range testTypes {
+		layers[i] = g.getTestTypeDisplayName(testType)
+	}
+
+	labels := make([]string, len(packageNames))
+	for i, name := range packageNames {
+		labels[i] = mermaidString(name)
+	}
+
+	sb.WriteString("xychart-beta\n")
+	sb.WriteString(fmt.Sprintf("    title %s\n", mermaidString("Тесты по пакетам (слои сверху вниз: "+strings.Join(layers, ", ")+")")))
+	sb.WriteString(fmt.Sprintf("    x-axis [%s]\n", strings.Join(labels, ", ")))
+	sb.WriteString(fmt.Sprintf("    y-axis %s 0 --> %d\n", mermaidString("Тестов"), maxTotal))
+
+	for layer := range testTypes {
+		values := make([]string, len(packageNames))
+		for i := range packageNames {
+			sum := 0
+			for _, testType := range testTypes[layer:] {
+				sum += counts[i][testType]
+			}
+			values[i] = fmt.Sprintf("%d", sum)
+		}
+		sb.WriteString(fmt.Sprintf("    bar [%s]\n", strings.Join(values, ", ")))
+	}
+}
+
+// writeHierarchyMermaid записывает граф пакетов и тестов в формате Mermaid flowchart.
+// Если limit больше нуля, для пакета выводится не более limit тестов,
+// а остальные заменяются узлом «… и еще N».
+func (g *Generator) writeHierarchyMermaid(sb io.StringWriter, packages map[string]*types.PackageInfo, limit int) {
+	sb.WriteString("flowchart LR\n")
+	sb.WriteString(fmt.Sprintf("    root[%s]\n", mermaidString(g.config.Title)))
+
+	var skipped []string
+	for i, name := range sortedPackageNames(packages) {
+		pkgID := fmt.Sprintf("p%d", i)
+		sb.WriteString(fmt.Sprintf("    root --> %s[%s]\n", pkgID, mermaidString(name)))
+
+		tests, hidden := packages[name].Tests, 0
+		if limit > 0 && len(tests) > limit {
+			tests, hidden = tests[:limit], len(tests)-limit
+		}
+
+		for j, test := range tests {
+			testID := fmt.Sprintf("%s_t%d", pkgID, j)
+			label := test.Name + "<br/>" + g.getTestTypeDisplayName(test.Type)
+			sb.WriteString(fmt.Sprintf("    %s --> %s(%s)\n", pkgID, testID, mermaidString(label)))
+			if test.Skipped {
+				skipped = append(skipped, testID)
+			}
+		}
+		if hidden > 0 {
+			sb.WriteString(fmt.Sprintf("    %s --> %s_more(%s)\n", pkgID, pkgID,
+				mermaidString(fmt.Sprintf("… и еще %d", hidden))))
+		}
+	}
+
+	if len(skipped) > 0 {
+		sb.WriteString("    classDef skipped stroke-dasharray: 5 5,color:#888\n")
+		sb.WriteString(fmt.Sprintf("    class %s skipped\n", strings.Join(skipped, ",")))
+	}
+}
+
+// writeHierarchyDOT записывает граф пакетов и тестов в формате Graphviz DOT
+func (g *Generator) writeHierarchyDOT(sb io.StringWriter, packages map[string]*types.PackageInfo) {
+	sb.WriteString("digraph tests {\n")
+	sb.WriteString("    rankdir=LR;\n")
+	sb.WriteString("    node [shape=box, fontname=\"Helvetica\"];\n")
+	sb.WriteString(fmt.Sprintf("    root [label=%s, shape=folder];\n", dotString(g.config.Title)))
+
+	for i, name := range sortedPackageNames(packages) {
+		pkgID := fmt.Sprintf("p%d", i)
+		sb.WriteString(fmt.Sprintf("    %s [label=%s, shape=tab];\n", pkgID, dotString(name)))
+		sb.WriteString(fmt.Sprintf("    root -> %s;\n", pkgID))
+
+		for j, test := range packages[name].Tests {
+			testID := fmt.Sprintf("%s_t%d", pkgID, j)
+			style := ""
+			if test.Skipped {
+				style = ", style=dashed, fontcolor=gray"
+			}
+			sb.WriteString(fmt.Sprintf("    %s [label=%s%s];\n", testID,
+				dotString(test.Name+"\n"+g.getTestTypeDisplayName(test.Type)), style))
+			sb.WriteString(fmt.Sprintf("    %s -> %s;\n", pkgID, testID))
+		}
+	}
+
+	sb.WriteString("}\n")
+}
+
+// sortedPackageNames возвращает имена пакетов по алфавиту
+func sortedPackageNames(packages map[string]*types.PackageInfo) []string {
+	names := make([]string, 0, len(packages))
+	for name := range packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedTypes возвращает типы тестов из распределения по алфавиту
+func sortedTypes(distribution map[types.TestType]int) []types.TestType {
+	testTypes := make([]types.TestType, 0, len(distribution))
+	for testType := range distribution {
+		testTypes = append(testTypes, testType)
+	}
+	sort.Slice(testTypes, func(i, j int) bool { return testTypes[i] < testTypes[j] })
+	return testTypes
+}
+
+// mermaidString заключает текст в кавычки Mermaid, заменяя кавычки внутри на сущность
+func mermaidString(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, "#quot;") + `"`
+}
+
+// dotString заключает текст в кавычки DOT с экранированием
+func dotString(text string) string {
+	text = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text)
+	return `"` + text + `"`
+}
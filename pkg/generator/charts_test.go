@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seblex/testdoc/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createChartResult() *types.ParseResult {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"auth": {Name: "auth", Tests: []types.TestInfo{
+				{Name: "TestLogin", Type: types.UnitTest},
+				{Name: "TestLogout", Type: types.UnitTest},
+				{Name: "TestSession", Type: types.IntegrationTest, Skipped: true},
+			}},
+			"api": {Name: "api", Tests: []types.TestInfo{
+				{Name: "TestHandler", Type: types.IntegrationTest},
+			}},
+		},
+	}
+	result.CalculateStats()
+	return result
+}
+
+func TestGenerator_writeTypePie(t *testing.T) {
+	gen := New(nil)
+
+	var sb strings.Builder
+	gen.writeTypePie(&sb, &createChartResult().Stats)
+
+	assert.Equal(t, "pie showData title Распределение по типам\n"+
+		"    \"Интеграционные\" : 2\n"+
+		"    \"Модульные\" : 2\n", sb.String())
+}
+
+func TestGenerator_writeStatusPie(t *testing.T) {
+	gen := New(nil)
+
+	var sb strings.Builder
+	gen.writeStatusPie(&sb, &createChartResult().Stats)
+
+	assert.Contains(t, sb.String(), "\"Активные\" : 3")
+	assert.Contains(t, sb.String(), "\"Пропущенные\" : 1")
+}
+
+func TestGenerator_writePackageBars(t *testing.T) {
+	gen := New(nil)
+
+	var sb strings.Builder
+	gen.writePackageBars(&sb, createChartResult().Packages)
+
+	output := sb.String()
+	assert.Contains(t, output, "xychart-beta\n")
+	assert.Contains(t, output, "слои сверху вниз: Интеграционные, Модульные")
+	assert.Contains(t, output, `x-axis ["api", "auth"]`)
+	assert.Contains(t, output, `y-axis "Тестов" 0 --> 3`)
+	// Первая серия - все тесты пакета, вторая - только модульные
+	assert.Contains(t, output, "    bar [1, 3]\n    bar [0, 2]\n")
+}
+
+func TestGenerator_writeHierarchy(t *testing.T) {
+	gen := New(&types.Config{Title: `Project "X"`})
+	packages := createChartResult().Packages
+
+	var mermaid strings.Builder
+	gen.writeHierarchyMermaid(&mermaid, packages, 0)
+	assert.Contains(t, mermaid.String(), "flowchart LR\n")
+	assert.Contains(t, mermaid.String(), `root["Project #quot;X#quot;"]`)
+	assert.Contains(t, mermaid.String(), `root --> p1["auth"]`)
+	assert.Contains(t, mermaid.String(), `p1 --> p1_t0("TestLogin<br/>Модульные")`)
+	assert.Contains(t, mermaid.String(), "class p1_t2 skipped")
+	assert.NotContains(t, mermaid.String(), "_more")
+
+	// С ограничением лишние тесты пакета заменяются одним узлом
+	mermaid.Reset()
+	gen.writeHierarchyMermaid(&mermaid, packages, 2)
+	assert.Contains(t, mermaid.String(), `p1 --> p1_more("… и еще 1")`)
+	assert.Contains(t, mermaid.String(), `p1 --> p1_t1(`)
+	assert.NotContains(t, mermaid.String(), "p1_t2")
+	assert.NotContains(t, mermaid.String(), "p0_more")
+
+	var dot strings.Builder
+	gen.writeHierarchyDOT(&dot, packages)
+	assert.Contains(t, dot.String(), "digraph tests {\n")
+	assert.Contains(t, dot.String(), `root [label="Project \"X\"", shape=folder];`)
+	assert.Contains(t, dot.String(), `p1_t2 [label="TestSession\nИнтеграционные", style=dashed, fontcolor=gray];`)
+	assert.Contains(t, dot.String(), "p0 -> p0_t0;")
+	assert.True(t, strings.HasSuffix(dot.String(), "}\n"))
+}
+
+func TestGenerator_GenerateMarkdown_Charts(t *testing.T) {
+	result := createChartResult()
+
+	output := New(&types.Config{}).GenerateMarkdown(result)
+	assert.NotContains(t, output, "```mermaid")
+
+	output = New(&types.Config{Charts: true}).GenerateMarkdown(result)
+	assert.Equal(t, 4, strings.Count(output, "```mermaid\n"))
+	assert.Contains(t, output, "### Диаграммы")
+	assert.Contains(t, output, "### Структура тестов")
+	assert.True(t, strings.Index(output, "## Статистика тестов") < strings.Index(output, "### Диаграммы"))
+
+	// Во встроенном графе не больше maxEmbeddedTests тестов пакета, в файле - все
+	for i := 0; i < maxEmbeddedTests+5; i++ {
+		result.Packages["api"].Tests = append(result.Packages["api"].Tests,
+			types.TestInfo{Name: fmt.Sprintf("TestCase%d", i), Type: types.UnitTest})
+	}
+	result.CalculateStats()
+	output = New(&types.Config{Charts: true}).GenerateMarkdown(result)
+	assert.Contains(t, output, `p0 --> p0_more("… и еще 6")`)
+	assert.NotContains(t, output, `("TestCase14`)
+	assert.Contains(t, New(nil).GenerateCharts(result)["hierarchy.mmd"], `("TestCase14`)
+}
+
+func TestGenerator_WriteCharts(t *testing.T) {
+	gen := New(nil)
+	dir := filepath.Join(t.TempDir(), "charts")
+	result := createChartResult()
+
+	require.NoError(t, gen.WriteCharts(dir, result))
+
+	charts := gen.GenerateCharts(result)
+	require.Len(t, charts, 5)
+	for name, content := range charts {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data), name)
+	}
+	assert.True(t, strings.HasPrefix(charts["hierarchy.dot"], "digraph"))
+}
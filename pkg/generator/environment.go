@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// generateEnvironment генерирует чек-лист окружения, необходимого для запуска тестов
+func (g *Generator) generateEnvironment(sb io.StringWriter, checklist []types.EnvironmentItem) {
+	sb.WriteString("## Окружение для запуска\n\n")
+
+	for _, item := range checklist {
+		sb.WriteString(fmt.Sprintf("- [ ] %s — %s\n",
+			g.formatDependency(item.Dependency), g.formatTestCount(len(item.Tests))))
+	}
+	sb.WriteString("\n")
+}
+
+// formatDependencies форматирует зависимости теста через запятую
+func (g *Generator) formatDependencies(deps []types.Dependency) string {
+	descriptions := make([]string, len(deps))
+	for i, dep := range deps {
+		descriptions[i] = g.formatDependency(dep)
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// formatDependency форматирует зависимость: вид и имя ресурса
+func (g *Generator) formatDependency(dep types.Dependency) string {
+	if dep.Kind == types.DependencyCustom {
+		return dep.Name
+	}
+	if dep.Name == "" {
+		return g.getDependencyKindDisplayName(dep.Kind)
+	}
+	return fmt.Sprintf("%s `%s`", g.getDependencyKindDisplayName(dep.Kind), dep.Name)
+}
+
+// formatTestCount возвращает количество тестов с согласованным окончанием
+func (g *Generator) formatTestCount(count int) string {
+	switch {
+	case count%10 == 1 && count%100 != 11:
+		return fmt.Sprintf("%d тест", count)
+	case count%10 >= 2 && count%10 <= 4 && (count%100 < 12 || count%100 > 14):
+		return fmt.Sprintf("%d теста", count)
+	default:
+		return fmt.Sprintf("%d тестов", count)
+	}
+}
+
+// getDependencyKindDisplayName возвращает отображаемое имя вида зависимости
+func (g *Generator) getDependencyKindDisplayName(kind types.DependencyKind) string {
+	switch kind {
+	case types.DependencyHTTPServer:
+		return "HTTP сервер"
+	case types.DependencyDatabase:
+		return "База данных"
+	case types.DependencyEnv:
+		return "Переменная окружения"
+	case types.DependencySetenv:
+		return "Устанавливает переменную"
+	case types.DependencyTempDir:
+		return "Временная директория"
+	case types.DependencyContainer:
+		return "Контейнер"
+	case types.DependencyDocker:
+		return "Docker"
+	case types.DependencyNetwork:
+		return "Сетевой адрес"
+	default:
+		return string(kind)
+	}
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func TestGenerator_generateEnvironment(t *testing.T) {
+	gen := New(nil)
+
+	checklist := []types.EnvironmentItem{
+		{Dependency: types.Dependency{Kind: types.DependencyDatabase, Name: "postgres"}, Tests: []string{"store.TestSave"}},
+		{Dependency: types.Dependency{Kind: types.DependencyEnv, Name: "DATABASE_URL"}, Tests: []string{"a.A", "b.B", "c.C"}},
+		{Dependency: types.Dependency{Kind: types.DependencyCustom, Name: "VPN"}, Tests: []string{"a.A", "b.B", "c.C", "d.D", "e.E"}},
+	}
+
+	var sb strings.Builder
+	gen.generateEnvironment(&sb, checklist)
+
+	output := sb.String()
+	assert.Contains(t, output, "## Окружение для запуска")
+	assert.Contains(t, output, "- [ ] База данных `postgres` — 1 тест\n")
+	assert.Contains(t, output, "- [ ] Переменная окружения `DATABASE_URL` — 3 теста\n")
+	assert.Contains(t, output, "- [ ] VPN — 5 тестов\n")
+}
+
+func TestGenerator_generateTestSection_Dependencies(t *testing.T) {
+	gen := New(nil)
+
+	testInfo := types.TestInfo{
+		Name:    "TestOrders",
+		Type:    types.IntegrationTest,
+		Package: "orders",
+		File:    "orders_test.go",
+		Line:    12,
+		Dependencies: []types.Dependency{
+			{Kind: types.DependencyDatabase, Name: "postgres"},
+			{Kind: types.DependencyTempDir},
+		},
+	}
+
+	var sb strings.Builder
+	gen.generateTestSection(&sb, testInfo)
+
+	assert.Contains(t, sb.String(), "| **Требует** | База данных `postgres`, Временная директория |")
+}
+
+func TestGenerator_formatTestCount(t *testing.T) {
+	gen := New(nil)
+
+	assert.Equal(t, "1 тест", gen.formatTestCount(1))
+	assert.Equal(t, "2 теста", gen.formatTestCount(2))
+	assert.Equal(t, "11 тестов", gen.formatTestCount(11))
+	assert.Equal(t, "21 тест", gen.formatTestCount(21))
+	assert.Equal(t, "0 тестов", gen.formatTestCount(0))
+}
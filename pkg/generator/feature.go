@@ -0,0 +1,149 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// GenerateFeatures генерирует файлы Cucumber .feature, по одному на пакет.
+// Возвращает отображение имени файла в его содержимое. В файлы попадают
+// только тест-кейсы со сценариями (@given/@when/@then); пакеты без
+// сценариев пропускаются.
+func (g *Generator) GenerateFeatures(result *types.ParseResult) map[string]string {
+	features := make(map[string]string)
+
+	var packageNames []string
+	for name := range result.Packages {
+		packageNames = append(packageNames, name)
+	}
+	sort.Strings(packageNames)
+
+	for _, packageName := range packageNames {
+		var sb strings.Builder
+		if g.generateFeature(&sb, result.Packages[packageName]) {
+			features[packageName+".feature"] = sb.String()
+		}
+	}
+
+	return features
+}
+
+// WriteFeatures записывает .feature файлы в директорию dir, создавая ее при необходимости.
+// Каждый файл записывается по мере генерации.
+func (g *Generator) WriteFeatures(dir string, result *types.ParseResult) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for packageName, pkg := range result.Packages {
+		if !hasScenarios(pkg) {
+			continue
+		}
+		if err := g.writeFeatureFile(filepath.Join(dir, packageName+".feature"), pkg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteFeature записывает Feature пакета в w. Пакет без сценариев ничего не записывает.
+func (g *Generator) WriteFeature(w io.Writer, pkg *types.PackageInfo) error {
+	sb := newDocWriter(w)
+	g.generateFeature(sb, pkg)
+	return sb.Flush()
+}
+
+// writeFeatureFile создает .feature файл пакета
+func (g *Generator) writeFeatureFile(filename string, pkg *types.PackageInfo) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := g.WriteFeature(f, pkg); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// hasScenarios проверяет, есть ли в пакете тест-кейсы со сценариями
+func hasScenarios(pkg *types.PackageInfo) bool {
+	for _, test := range pkg.Tests {
+		for _, testCase := range test.TestCases {
+			if len(testCase.Scenario) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generateFeature генерирует Feature для пакета. Возвращает false, если сценариев нет.
+func (g *Generator) generateFeature(sb io.StringWriter, pkg *types.PackageInfo) bool {
+	tests := make([]types.TestInfo, len(pkg.Tests))
+	copy(tests, pkg.Tests)
+	sort.SliceStable(tests, func(i, j int) bool {
+		if tests[i].File != tests[j].File {
+			return tests[i].File < tests[j].File
+		}
+		return tests[i].Line < tests[j].Line
+	})
+
+	written := false
+	for _, test := range tests {
+		for _, testCase := range test.TestCases {
+			if len(testCase.Scenario) == 0 {
+				continue
+			}
+
+			if !written {
+				sb.WriteString(fmt.Sprintf("Feature: %s\n", pkg.Name))
+				if pkg.Description != "" {
+					sb.WriteString(fmt.Sprintf("  %s\n", pkg.Description))
+				}
+				written = true
+			}
+
+			sb.WriteString("\n")
+			if tags := g.featureTags(test); tags != "" {
+				sb.WriteString(fmt.Sprintf("  %s\n", tags))
+			}
+			sb.WriteString(fmt.Sprintf("  Scenario: %s\n", testCase.Name))
+			sb.WriteString(fmt.Sprintf("    # %s (%s:%d)\n", test.Name, test.File, test.Line))
+			if testCase.Description != "" {
+				sb.WriteString(fmt.Sprintf("    # %s\n", testCase.Description))
+			}
+
+			for _, step := range testCase.Scenario {
+				sb.WriteString(fmt.Sprintf("    %s %s\n", step.Keyword, step.Text))
+			}
+		}
+	}
+
+	return written
+}
+
+// featureTags формирует строку тегов Gherkin из типа, тегов и статуса теста
+func (g *Generator) featureTags(test types.TestInfo) string {
+	var tags []string
+	if test.Type != "" {
+		tags = append(tags, "@"+string(test.Type))
+	}
+	for _, tag := range test.Tags {
+		if tag = strings.ReplaceAll(strings.TrimSpace(tag), " ", "_"); tag != "" {
+			tags = append(tags, "@"+tag)
+		}
+	}
+	if test.Skipped {
+		tags = append(tags, "@skip")
+	}
+	return strings.Join(tags, " ")
+}
@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func featureTestResult() *types.ParseResult {
+	return &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"checkout": {
+				Name:        "checkout",
+				Description: "Оформление заказа",
+				Tests: []types.TestInfo{
+					{
+						Name:    "TestCheckout",
+						Type:    types.E2ETest,
+						File:    "checkout_test.go",
+						Line:    12,
+						Tags:    []string{"payment", "happy path"},
+						Skipped: true,
+						TestCases: []types.TestCase{
+							{
+								Name:        "Paying by card",
+								Description: "happy path",
+								Scenario: []types.ScenarioStep{
+									{Keyword: types.Given, Text: "a registered user"},
+									{Keyword: types.When, Text: "the user pays by card"},
+									{Keyword: types.Then, Text: "the order is confirmed"},
+								},
+							},
+							{Name: "Without scenario"},
+						},
+					},
+				},
+			},
+			"plain": {
+				Name:  "plain",
+				Tests: []types.TestInfo{{Name: "TestPlain", TestCases: []types.TestCase{{Name: "case"}}}},
+			},
+		},
+	}
+}
+
+func TestGenerator_GenerateFeatures(t *testing.T) {
+	gen := New(nil)
+
+	features := gen.GenerateFeatures(featureTestResult())
+	require.Len(t, features, 1)
+
+	feature := features["checkout.feature"]
+	assert.True(t, strings.HasPrefix(feature, "Feature: checkout\n  Оформление заказа\n"))
+	assert.Contains(t, feature, "  @e2e @payment @happy_path @skip\n  Scenario: Paying by card\n")
+	assert.Contains(t, feature, "    # TestCheckout (checkout_test.go:12)\n")
+	assert.Contains(t, feature, "    Given a registered user\n    When the user pays by card\n    Then the order is confirmed\n")
+	assert.NotContains(t, feature, "Without scenario")
+}
+
+func TestGenerator_WriteFeatures(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "generator_features_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	dir := filepath.Join(tmpDir, "features")
+	require.NoError(t, New(nil).WriteFeatures(dir, featureTestResult()))
+
+	content, err := os.ReadFile(filepath.Join(dir, "checkout.feature"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Scenario: Paying by card")
+
+	_, err = os.Stat(filepath.Join(dir, "plain.feature"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestGenerator_WriteFeature(t *testing.T) {
+	result := featureTestResult()
+
+	var sb strings.Builder
+	require.NoError(t, New(nil).WriteFeature(&sb, result.Packages["checkout"]))
+	assert.Equal(t, New(nil).GenerateFeatures(result)["checkout.feature"], sb.String())
+
+	sb.Reset()
+	require.NoError(t, New(nil).WriteFeature(&sb, result.Packages["plain"]))
+	assert.Empty(t, sb.String())
+}
+
+func TestGenerator_generateTestSection_Scenario(t *testing.T) {
+	var sb strings.Builder
+	New(nil).generateTestSection(&sb, featureTestResult().Packages["checkout"].Tests[0])
+
+	output := sb.String()
+	assert.Contains(t, output, "**Сценарий:**")
+	assert.Contains(t, output, "- **Дано** a registered user\n- **Когда** the user pays by card\n- **Тогда** the order is confirmed\n")
+}
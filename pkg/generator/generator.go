@@ -2,13 +2,16 @@
 package generator
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"time"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
+	yaml "gopkg.in/yaml.v3"
 
 	"github.com/seblex/testdoc/pkg/types"
 )
@@ -31,106 +34,69 @@ func New(config *types.Config) *Generator {
 // GenerateMarkdown генерирует Markdown документацию из результата парсинга
 func (g *Generator) GenerateMarkdown(result *types.ParseResult) string {
 	var sb strings.Builder
+	// Запись в strings.Builder не возвращает ошибок
+	_ = g.WriteMarkdown(&sb, result)
+	return sb.String()
+}
 
-	// Заголовок документа
-	sb.WriteString(fmt.Sprintf("# %s\n\n", g.config.Title))
-	sb.WriteString(fmt.Sprintf("**Автор:** %s  \n", g.config.Author))
-	sb.WriteString(fmt.Sprintf("**Версия:** %s  \n", g.config.Version))
-	sb.WriteString(fmt.Sprintf("**Дата генерации:** %s\n\n", time.Now().Format("2006-01-02 15:04:05")))
+// WriteMarkdown записывает Markdown документацию в w по мере генерации,
+// не собирая документ целиком в памяти. Возвращает первую ошибку записи.
+func (g *Generator) WriteMarkdown(w io.Writer, result *types.ParseResult) error {
+	sb := newDocWriter(w)
+
+	g.generateHeader(sb)
 
-	// Оглавление
+	// Оглавление ссылается на заголовки, которые будут записаны ниже
+	anchors := g.collectAnchors(result.Packages)
 	sb.WriteString("## Оглавление\n\n")
-	if g.config.GroupByPackage {
-		g.generateTOCByPackage(&sb, result.Packages)
-	} else if g.config.GroupByType {
-		g.generateTOCByType(&sb, result.Packages)
-	} else {
-		g.generateSimpleTOC(&sb, result.Packages)
-	}
+	g.generateTOC(sb, result.Packages, anchors)
 
-	// Статистика
-	sb.WriteString("## Статистика тестов\n\n")
-	g.generateStatistics(&sb, &result.Stats)
+	g.generateBody(sb, result)
 
-	// Основной контент
-	if g.config.GroupByPackage {
-		g.generateContentByPackage(&sb, result.Packages)
-	} else if g.config.GroupByType {
-		g.generateContentByType(&sb, result.Packages)
-	} else {
-		g.generateSimpleContent(&sb, result.Packages)
-	}
-
-	return sb.String()
+	return sb.Flush()
 }
 
-// generateTOCByPackage генерирует оглавление по пакетам
-func (g *Generator) generateTOCByPackage(sb *strings.Builder, packages map[string]*types.PackageInfo) {
-	var packageNames []string
-	for name := range packages {
-		packageNames = append(packageNames, name)
-	}
-	sort.Strings(packageNames)
-
-	for _, packageName := range packageNames {
-		pkg := packages[packageName]
-		sb.WriteString(fmt.Sprintf("- [Пакет %s](#пакет-%s)\n", packageName, strings.ToLower(packageName)))
-
-		for _, test := range pkg.Tests {
-			anchor := strings.ToLower(strings.ReplaceAll(test.Name, "_", "-"))
-			sb.WriteString(fmt.Sprintf("  - [%s](#%s)\n", test.Name, anchor))
-		}
-	}
-	sb.WriteString("\n")
+// generateHeader генерирует заголовок документа
+func (g *Generator) generateHeader(sb io.StringWriter) {
+	sb.WriteString(fmt.Sprintf("# %s\n\n", g.config.Title))
+	sb.WriteString(fmt.Sprintf("**Автор:** %s  \n", g.config.Author))
+	sb.WriteString(fmt.Sprintf("**Версия:** %s  \n", g.config.Version))
+	sb.WriteString(fmt.Sprintf("**Дата генерации:** %s\n\n", time.Now().Format("2006-01-02 15:04:05")))
 }
 
-// generateTOCByType генерирует оглавление по типам тестов
-func (g *Generator) generateTOCByType(sb *strings.Builder, packages map[string]*types.PackageInfo) {
-	typeGroups := g.groupTestsByType(packages)
-
-	var testTypes []string
-	for testType := range typeGroups {
-		testTypes = append(testTypes, string(testType))
-	}
-	sort.Strings(testTypes)
-
-	for _, testType := range testTypes {
-		tests := typeGroups[types.TestType(testType)]
-		sb.WriteString(fmt.Sprintf("- [%s тесты](#%s-тесты)\n",
-			g.getTestTypeDisplayName(types.TestType(testType)),
-			strings.ToLower(testType)))
+// generateBody генерирует все разделы документа после оглавления
+func (g *Generator) generateBody(sb io.StringWriter, result *types.ParseResult) {
+	// Статистика
+	sb.WriteString("## Статистика тестов\n\n")
+	g.generateStatistics(sb, &result.Stats)
 
-		for _, test := range tests {
-			anchor := strings.ToLower(strings.ReplaceAll(test.Name, "_", "-"))
-			sb.WriteString(fmt.Sprintf("  - [%s](#%s)\n", test.Name, anchor))
-		}
+	if g.config.Charts {
+		g.generateCharts(sb, result)
 	}
-	sb.WriteString("\n")
-}
 
-// generateSimpleTOC генерирует простое оглавление
-func (g *Generator) generateSimpleTOC(sb *strings.Builder, packages map[string]*types.PackageInfo) {
-	var allTests []types.TestInfo
-	for _, pkg := range packages {
-		allTests = append(allTests, pkg.Tests...)
+	if g.config.Traceability {
+		g.generateTraceability(sb, result)
 	}
 
-	sort.Slice(allTests, func(i, j int) bool {
-		return allTests[i].Name < allTests[j].Name
-	})
+	g.generateRisk(sb, result.Risk())
 
-	for _, test := range allTests {
-		anchor := strings.ToLower(strings.ReplaceAll(test.Name, "_", "-"))
-		sb.WriteString(fmt.Sprintf("- [%s](#%s)\n", test.Name, anchor))
+	if checklist := result.Environment(); len(checklist) > 0 {
+		g.generateEnvironment(sb, checklist)
 	}
-	sb.WriteString("\n")
+
+	// Основной контент
+	g.generateContent(sb, result.Packages)
 }
 
 // generateStatistics генерирует статистику тестов
-func (g *Generator) generateStatistics(sb *strings.Builder, stats *types.Statistics) {
+func (g *Generator) generateStatistics(sb io.StringWriter, stats *types.Statistics) {
 	sb.WriteString(fmt.Sprintf("- **Всего тестов:** %d\n", stats.TotalTests))
 	sb.WriteString(fmt.Sprintf("- **Активных тестов:** %d\n", stats.ActiveTests))
 	sb.WriteString(fmt.Sprintf("- **Пропущенных тестов:** %d\n", stats.SkippedTests))
+	if stats.ConditionalTests > 0 {
+		sb.WriteString(fmt.Sprintf("- **Условно пропускаемых:** %d\n", stats.ConditionalTests))
+	}
+	g.generateTraitsStatistics(sb, stats)
 	sb.WriteString(fmt.Sprintf("- **Пакетов:** %d\n\n", stats.PackageCount))
 
 	sb.WriteString("### Распределение по типам\n\n")
@@ -148,84 +114,60 @@ func (g *Generator) generateStatistics(sb *strings.Builder, stats *types.Statist
 			g.getTestTypeDisplayName(testType), count, percentage))
 	}
 	sb.WriteString("\n")
-}
-
-// generateContentByPackage генерирует контент, сгруппированный по пакетам
-func (g *Generator) generateContentByPackage(sb *strings.Builder, packages map[string]*types.PackageInfo) {
-	var packageNames []string
-	for name := range packages {
-		packageNames = append(packageNames, name)
-	}
-	sort.Strings(packageNames)
-
-	for _, packageName := range packageNames {
-		pkg := packages[packageName]
-		sb.WriteString(fmt.Sprintf("## Пакет %s\n\n", packageName))
 
-		if pkg.Description != "" {
-			sb.WriteString(fmt.Sprintf("%s\n\n", pkg.Description))
+	if len(stats.Owners) > 0 {
+		sb.WriteString("### Распределение по владельцам\n\n")
+		var owners []string
+		for owner := range stats.Owners {
+			owners = append(owners, owner)
 		}
+		sort.Strings(owners)
 
-		sb.WriteString(fmt.Sprintf("**Путь:** `%s`\n\n", pkg.Path))
-
-		for _, test := range pkg.Tests {
-			g.generateTestSection(sb, test)
+		for _, owner := range owners {
+			ownerStats := stats.Owners[owner]
+			sb.WriteString(fmt.Sprintf("- **%s:** %d (пропущено: %d)\n",
+				owner, ownerStats.TotalTests, ownerStats.SkippedTests))
 		}
+		sb.WriteString("\n")
 	}
-}
-
-// generateContentByType генерирует контент, сгруппированный по типам тестов
-func (g *Generator) generateContentByType(sb *strings.Builder, packages map[string]*types.PackageInfo) {
-	typeGroups := g.groupTestsByType(packages)
-
-	var testTypes []string
-	for testType := range typeGroups {
-		testTypes = append(testTypes, string(testType))
-	}
-	sort.Strings(testTypes)
-
-	for _, testType := range testTypes {
-		tests := typeGroups[types.TestType(testType)]
-		sb.WriteString(fmt.Sprintf("## %s тесты\n\n", g.getTestTypeDisplayName(types.TestType(testType))))
 
-		for _, test := range tests {
-			g.generateTestSection(sb, test)
-		}
-	}
+	g.generateQuality(sb, stats)
 }
 
-// generateSimpleContent генерирует простой контент
-func (g *Generator) generateSimpleContent(sb *strings.Builder, packages map[string]*types.PackageInfo) {
-	var allTests []types.TestInfo
-	for _, pkg := range packages {
-		allTests = append(allTests, pkg.Tests...)
-	}
-
-	sort.Slice(allTests, func(i, j int) bool {
-		return allTests[i].Name < allTests[j].Name
-	})
+// languages сопоставляет значения параметра language с языками
+var languages = map[string]language.Tag{
+	"ru":      language.Russian,
+	"russian": language.Russian,
+	"en":      language.English,
+	"english": language.English,
+}
 
-	sb.WriteString("## Тесты\n\n")
-	for _, test := range allTests {
-		g.generateTestSection(sb, test)
+// Languages возвращает отсортированный список допустимых значений параметра language
+func Languages() []string {
+	names := make([]string, 0, len(languages))
+	for name := range languages {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	return names
 }
 
 // getLanguage возвращает язык для заголовков на основе конфигурации
 func (g *Generator) getLanguage() language.Tag {
-	switch g.config.Language {
-	case "en", "english":
-		return language.English
-	case "ru", "russian":
-		return language.Russian
-	default:
-		return language.Russian // По умолчанию русский
+	if tag, ok := languages[g.config.Language]; ok {
+		return tag
 	}
+	return language.Russian // По умолчанию русский
+}
+
+// generateTestSection генерирует секцию для отдельного теста с заголовком третьего уровня
+func (g *Generator) generateTestSection(sb io.StringWriter, test types.TestInfo) {
+	g.generateTestSectionAt(sb, test, 3)
 }
 
-// generateTestSection генерирует секцию для отдельного теста
-func (g *Generator) generateTestSection(sb *strings.Builder, test types.TestInfo) {
-	sb.WriteString(fmt.Sprintf("### %s\n\n", test.Name))
+// generateTestSectionAt генерирует секцию теста с заголовком уровня level
+func (g *Generator) generateTestSectionAt(sb io.StringWriter, test types.TestInfo, level int) {
+	sb.WriteString(fmt.Sprintf("%s %s\n\n", heading(level), test.Name))
 
 	// Базовая информация
 	sb.WriteString("| Параметр | Значение |\n")
@@ -243,10 +185,42 @@ func (g *Generator) generateTestSection(sb *strings.Builder, test types.TestInfo
 		sb.WriteString("| **Статус** | ✅ Активен |\n")
 	}
 
+	if test.Priority != "" {
+		sb.WriteString(fmt.Sprintf("| **Приоритет** | %s |\n", g.formatPriority(test.Priority)))
+	}
+
+	if test.Severity != "" {
+		sb.WriteString(fmt.Sprintf("| **Серьезность** | %s |\n", g.formatSeverity(test.Severity)))
+	}
+
+	if test.Run != nil {
+		sb.WriteString(fmt.Sprintf("| **Последний запуск** | %s |\n", g.formatRun(*test.Run)))
+	}
+
+	if skips := test.ConditionalSkips(); len(skips) > 0 {
+		descriptions := make([]string, len(skips))
+		for i, skip := range skips {
+			descriptions[i] = g.formatSkip(skip)
+		}
+		sb.WriteString(fmt.Sprintf("| **Условный пропуск** | %s |\n", strings.Join(descriptions, "<br>")))
+	}
+
 	if test.Author != "" {
 		sb.WriteString(fmt.Sprintf("| **Автор** | %s |\n", test.Author))
 	}
 
+	if len(test.Owners) > 0 {
+		sb.WriteString(fmt.Sprintf("| **Владельцы** | %s |\n", strings.Join(test.Owners, ", ")))
+	}
+
+	if !test.Traits.Empty() {
+		sb.WriteString(fmt.Sprintf("| **Выполнение** | %s |\n", g.formatTraits(test.Traits)))
+	}
+
+	if len(test.Dependencies) > 0 {
+		sb.WriteString(fmt.Sprintf("| **Требует** | %s |\n", g.formatDependencies(test.Dependencies)))
+	}
+
 	if !test.Created.IsZero() {
 		sb.WriteString(fmt.Sprintf("| **Создан** | %s |\n", test.Created.Format("2006-01-02")))
 	}
@@ -255,6 +229,18 @@ func (g *Generator) generateTestSection(sb *strings.Builder, test types.TestInfo
 		sb.WriteString(fmt.Sprintf("| **Обновлен** | %s |\n", test.Updated.Format("2006-01-02")))
 	}
 
+	if len(test.Requirements) > 0 {
+		sb.WriteString(fmt.Sprintf("| **Требования** | %s |\n", g.formatLinks(types.LinkRequirement, test.Requirements)))
+	}
+
+	if len(test.Stories) > 0 {
+		sb.WriteString(fmt.Sprintf("| **Истории** | %s |\n", g.formatLinks(types.LinkStory, test.Stories)))
+	}
+
+	if len(test.Issues) > 0 {
+		sb.WriteString(fmt.Sprintf("| **Задачи** | %s |\n", g.formatLinks(types.LinkIssue, test.Issues)))
+	}
+
 	if len(test.Tags) > 0 {
 		tags := make([]string, len(test.Tags))
 		for i, tag := range test.Tags {
@@ -273,7 +259,7 @@ func (g *Generator) generateTestSection(sb *strings.Builder, test types.TestInfo
 
 	// Тест-кейсы
 	if len(test.TestCases) > 0 {
-		sb.WriteString("#### Тест-кейсы\n\n")
+		sb.WriteString(heading(level+1) + " Тест-кейсы\n\n")
 		for i, testCase := range test.TestCases {
 			sb.WriteString(fmt.Sprintf("**%d. %s**\n\n", i+1, testCase.Name))
 
@@ -281,12 +267,20 @@ func (g *Generator) generateTestSection(sb *strings.Builder, test types.TestInfo
 				sb.WriteString(fmt.Sprintf("%s\n\n", testCase.Description))
 			}
 
+			if len(testCase.Scenario) > 0 {
+				sb.WriteString("**Сценарий:**\n\n")
+				for _, step := range testCase.Scenario {
+					sb.WriteString(fmt.Sprintf("- **%s** %s\n", g.getGherkinKeywordDisplayName(step.Keyword), step.Text))
+				}
+				sb.WriteString("\n")
+			}
+
 			if testCase.Input != "" {
-				sb.WriteString(fmt.Sprintf("- **Входные данные:** %s\n", testCase.Input))
+				g.generatePayload(sb, "Входные данные", testCase.Input)
 			}
 
 			if testCase.Expected != "" {
-				sb.WriteString(fmt.Sprintf("- **Ожидаемый результат:** %s\n", testCase.Expected))
+				g.generatePayload(sb, "Ожидаемый результат", testCase.Expected)
 			}
 
 			if len(testCase.Steps) > 0 {
@@ -297,6 +291,9 @@ func (g *Generator) generateTestSection(sb *strings.Builder, test types.TestInfo
 						sb.WriteString(fmt.Sprintf(" → %s", step.Expected))
 					}
 					sb.WriteString("\n")
+					if step.Description != "" {
+						sb.WriteString(fmt.Sprintf("   %s\n", step.Description))
+					}
 				}
 			}
 
@@ -306,7 +303,7 @@ func (g *Generator) generateTestSection(sb *strings.Builder, test types.TestInfo
 
 	// Дополнительные метаданные
 	if len(test.Metadata) > 0 {
-		sb.WriteString("#### Дополнительная информация\n\n")
+		sb.WriteString(heading(level+1) + " Дополнительная информация\n\n")
 		caser := cases.Title(g.getLanguage())
 		for key, value := range test.Metadata {
 			sb.WriteString(fmt.Sprintf("- **%s:** %s\n", caser.String(key), value))
@@ -317,24 +314,114 @@ func (g *Generator) generateTestSection(sb *strings.Builder, test types.TestInfo
 	sb.WriteString("---\n\n")
 }
 
-// groupTestsByType группирует тесты по типам
-func (g *Generator) groupTestsByType(packages map[string]*types.PackageInfo) map[types.TestType][]types.TestInfo {
-	typeGroups := make(map[types.TestType][]types.TestInfo)
+// generatePayload выводит входные данные или ожидаемый результат тест-кейса.
+// Многострочные значения выводятся блоком кода с определением формата.
+func (g *Generator) generatePayload(sb io.StringWriter, label, payload string) {
+	if !strings.Contains(payload, "\n") {
+		sb.WriteString(fmt.Sprintf("- **%s:** %s\n", label, payload))
+		return
+	}
 
-	for _, pkg := range packages {
-		for _, test := range pkg.Tests {
-			typeGroups[test.Type] = append(typeGroups[test.Type], test)
+	sb.WriteString(fmt.Sprintf("\n**%s:**\n\n", label))
+	sb.WriteString(fmt.Sprintf("```%s\n%s\n```\n\n", detectPayloadFormat(payload), payload))
+}
+
+// detectPayloadFormat определяет язык подсветки для блока данных: json, yaml или text
+func detectPayloadFormat(payload string) string {
+	if json.Valid([]byte(payload)) {
+		return "json"
+	}
+
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(payload), &value); err == nil {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return "yaml"
 		}
 	}
 
-	// Сортируем тесты в каждой группе
-	for testType := range typeGroups {
-		sort.Slice(typeGroups[testType], func(i, j int) bool {
-			return typeGroups[testType][i].Name < typeGroups[testType][j].Name
-		})
+	return "text"
+}
+
+// getOwnerDisplayName возвращает заголовок группы владельца
+func (g *Generator) getOwnerDisplayName(owner string) string {
+	if owner == "" {
+		return "Без владельца"
+	}
+	return fmt.Sprintf("Владелец %s", owner)
+}
+
+// getGherkinKeywordDisplayName возвращает отображаемое имя ключевого слова сценария
+func (g *Generator) getGherkinKeywordDisplayName(keyword types.GherkinKeyword) string {
+	switch keyword {
+	case types.Given:
+		return "Дано"
+	case types.When:
+		return "Когда"
+	case types.Then:
+		return "Тогда"
+	case types.And:
+		return "И"
+	case types.But:
+		return "Но"
+	default:
+		return string(keyword)
+	}
+}
+
+// formatSkip формирует описание условного пропуска: вид, условие и причину
+func (g *Generator) formatSkip(skip types.SkipInfo) string {
+	description := g.getSkipKindDisplayName(skip.Kind)
+	if skip.Detail != "" {
+		description += fmt.Sprintf(" `%s`", skip.Detail)
 	}
+	if skip.Condition != "" {
+		description += fmt.Sprintf(" (`%s`)", strings.ReplaceAll(skip.Condition, "|", "\\|"))
+	}
+	if skip.Reason != "" {
+		description += " — " + skip.Reason
+	}
+	return description
+}
 
-	return typeGroups
+// formatRun описывает итог последнего запуска теста
+func (g *Generator) formatRun(run types.RunResult) string {
+	var outcome string
+	switch run.Outcome {
+	case "pass":
+		outcome = "✅ Пройден"
+	case "fail":
+		outcome = "❌ Упал"
+	case "skip":
+		outcome = "⏭️ Пропущен"
+	default:
+		outcome = run.Outcome
+	}
+	return fmt.Sprintf("%s за %s", outcome, run.Elapsed.Round(time.Millisecond))
+}
+
+// getSkipKindDisplayName возвращает отображаемое имя вида пропуска
+func (g *Generator) getSkipKindDisplayName(kind types.SkipKind) string {
+	switch kind {
+	case types.SkipAlways:
+		return "Всегда"
+	case types.SkipShort:
+		return "В режиме -short"
+	case types.SkipEnv:
+		return "Переменная окружения"
+	case types.SkipOS:
+		return "ОС"
+	case types.SkipArch:
+		return "Архитектура"
+	case types.SkipBuildTag:
+		return "Build-теги"
+	case types.SkipSubtest:
+		return "Подтест"
+	case types.SkipCondition:
+		return "Условие"
+	default:
+		return string(kind)
+	}
 }
 
 // getTestTypeDisplayName возвращает отображаемое имя типа теста
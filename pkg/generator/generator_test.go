@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/seblex/testdoc/pkg/types"
 )
@@ -147,7 +148,7 @@ func TestGenerator_getTestTypeDisplayName(t *testing.T) {
 	}
 }
 
-func TestGenerator_groupTestsByType(t *testing.T) {
+func TestGenerator_buildSections_ByType(t *testing.T) {
 	gen := New(nil)
 
 	testInfo1 := types.TestInfo{Name: "TestUnit1", Type: types.UnitTest}
@@ -156,26 +157,27 @@ func TestGenerator_groupTestsByType(t *testing.T) {
 
 	packages := map[string]*types.PackageInfo{
 		"pkg1": {
-			Tests: []types.TestInfo{testInfo1, testInfo3},
+			Tests: []types.TestInfo{testInfo2, testInfo3},
 		},
 		"pkg2": {
-			Tests: []types.TestInfo{testInfo2},
+			Tests: []types.TestInfo{testInfo1},
 		},
 	}
 
-	groups := gen.groupTestsByType(packages)
+	sections := gen.buildSections(packages, []string{GroupType})
 
-	assert.Len(t, groups, 2)
-	assert.Len(t, groups[types.UnitTest], 2)
-	assert.Len(t, groups[types.IntegrationTest], 1)
+	require.Len(t, sections, 2)
+	assert.Equal(t, string(types.IntegrationTest), sections[0].value)
+	assert.Len(t, sections[0].tests, 1)
+	assert.Equal(t, string(types.UnitTest), sections[1].value)
 
 	// Проверяем сортировку
-	unitTests := groups[types.UnitTest]
+	unitTests := sections[1].tests
 	assert.Equal(t, "TestUnit1", unitTests[0].Name)
 	assert.Equal(t, "TestUnit2", unitTests[1].Name)
 }
 
-func TestGenerator_generateTOCByType(t *testing.T) {
+func TestGenerator_generateTOC_ByType(t *testing.T) {
 	gen := New(&types.Config{GroupByType: true})
 
 	testInfo1 := types.TestInfo{Name: "TestUnit", Type: types.UnitTest}
@@ -188,11 +190,11 @@ func TestGenerator_generateTOCByType(t *testing.T) {
 	}
 
 	var sb strings.Builder
-	gen.generateTOCByType(&sb, packages)
+	gen.generateTOC(&sb, packages, nil)
 
 	toc := sb.String()
-	assert.Contains(t, toc, "- [Интеграционные тесты](#integration-тесты)")
-	assert.Contains(t, toc, "- [Модульные тесты](#unit-тесты)")
+	assert.Contains(t, toc, "- [Интеграционные тесты](#интеграционные-тесты)")
+	assert.Contains(t, toc, "- [Модульные тесты](#модульные-тесты)")
 	assert.Contains(t, toc, "- [TestUnit](#testunit)")
 	assert.Contains(t, toc, "- [TestIntegration](#testintegration)")
 }
@@ -325,7 +327,45 @@ func TestGenerator_generateTestSection_Skipped(t *testing.T) {
 	assert.Contains(t, output, "| **Причина пропуска** | External service unavailable |")
 }
 
-func TestGenerator_generateContentByPackage(t *testing.T) {
+func TestGenerator_generateTestSection_ConditionalSkip(t *testing.T) {
+	gen := New(nil)
+
+	testInfo := types.TestInfo{
+		Name:    "TestDatabase",
+		Type:    types.IntegrationTest,
+		Package: "example",
+		File:    "example_test.go",
+		Line:    30,
+		Skips: []types.SkipInfo{
+			{Kind: types.SkipBuildTag, Condition: "integration"},
+			{Kind: types.SkipEnv, Condition: `dsn == ""`, Detail: "DATABASE_URL", Reason: "no database"},
+			{Kind: types.SkipCondition, Condition: "a || b"},
+		},
+	}
+
+	var sb strings.Builder
+	gen.generateTestSection(&sb, testInfo)
+
+	output := sb.String()
+	assert.Contains(t, output, "| **Статус** | ✅ Активен |")
+	assert.Contains(t, output, "| **Условный пропуск** | Build-теги (`integration`)<br>"+
+		"Переменная окружения `DATABASE_URL` (`dsn == \"\"`) — no database<br>"+
+		"Условие (`a \\|\\| b`) |")
+}
+
+func TestGenerator_generateStatistics_Conditional(t *testing.T) {
+	gen := New(nil)
+
+	var sb strings.Builder
+	gen.generateStatistics(&sb, &types.Statistics{TotalTests: 2, ActiveTests: 2, ConditionalTests: 1})
+	assert.Contains(t, sb.String(), "- **Условно пропускаемых:** 1\n")
+
+	sb.Reset()
+	gen.generateStatistics(&sb, &types.Statistics{TotalTests: 2, ActiveTests: 2})
+	assert.NotContains(t, sb.String(), "Условно пропускаемых")
+}
+
+func TestGenerator_generateContent_ByPackage(t *testing.T) {
 	config := &types.Config{GroupByPackage: true}
 	gen := New(config)
 
@@ -347,7 +387,7 @@ func TestGenerator_generateContentByPackage(t *testing.T) {
 	}
 
 	var sb strings.Builder
-	gen.generateContentByPackage(&sb, packages)
+	gen.generateContent(&sb, packages)
 
 	output := sb.String()
 	assert.Contains(t, output, "## Пакет example")
@@ -356,7 +396,7 @@ func TestGenerator_generateContentByPackage(t *testing.T) {
 	assert.Contains(t, output, "### TestExample")
 }
 
-func TestGenerator_generateContentByType(t *testing.T) {
+func TestGenerator_generateContent_ByType(t *testing.T) {
 	config := &types.Config{GroupByType: true}
 	gen := New(config)
 
@@ -370,7 +410,7 @@ func TestGenerator_generateContentByType(t *testing.T) {
 	}
 
 	var sb strings.Builder
-	gen.generateContentByType(&sb, packages)
+	gen.generateContent(&sb, packages)
 
 	output := sb.String()
 	assert.Contains(t, output, "## Интеграционные тесты")
@@ -379,8 +419,8 @@ func TestGenerator_generateContentByType(t *testing.T) {
 	assert.Contains(t, output, "### TestIntegration")
 }
 
-func TestGenerator_generateSimpleContent(t *testing.T) {
-	gen := New(nil)
+func TestGenerator_generateContent_Simple(t *testing.T) {
+	gen := New(&types.Config{})
 
 	testInfo1 := types.TestInfo{Name: "TestB", Type: types.UnitTest}
 	testInfo2 := types.TestInfo{Name: "TestA", Type: types.IntegrationTest}
@@ -392,7 +432,7 @@ func TestGenerator_generateSimpleContent(t *testing.T) {
 	}
 
 	var sb strings.Builder
-	gen.generateSimpleContent(&sb, packages)
+	gen.generateContent(&sb, packages)
 
 	output := sb.String()
 	assert.Contains(t, output, "## Тесты")
@@ -500,3 +540,67 @@ func TestGenerator_LanguageInMetadata(t *testing.T) {
 	assert.Contains(t, outputEn, "- **Priority:** high")
 	assert.Contains(t, outputEn, "- **Complexity:** low")
 }
+
+func TestGenerator_generateContent_ByOwner(t *testing.T) {
+	gen := New(&types.Config{GroupByOwner: true})
+
+	packages := map[string]*types.PackageInfo{
+		"payments": {
+			Tests: []types.TestInfo{
+				{Name: "TestCharge", Type: types.UnitTest, Owners: []string{"@org/payments"}},
+				{Name: "TestRefund", Type: types.UnitTest, Owners: []string{"@org/payments"},
+					Skipped: true, SkipReason: "flaky"},
+				{Name: "TestOrphan", Type: types.UnitTest},
+			},
+		},
+	}
+
+	var toc strings.Builder
+	gen.generateTOC(&toc, packages, nil)
+	assert.Contains(t, toc.String(), "- [Владелец @org/payments](#владелец-orgpayments)")
+	assert.Contains(t, toc.String(), "- [Без владельца](#без-владельца)")
+
+	var sb strings.Builder
+	gen.generateContent(&sb, packages)
+
+	output := sb.String()
+	assert.Contains(t, output, "## Владелец @org/payments")
+	assert.Contains(t, output, "**Всего тестов:** 2, **пропущено:** 1")
+	assert.Contains(t, output, "- `TestRefund` — flaky")
+	assert.Contains(t, output, "| **Владельцы** | @org/payments |")
+	assert.True(t, strings.Index(output, "## Владелец @org/payments") < strings.Index(output, "## Без владельца"),
+		"tests without owner should come last")
+}
+
+func TestGenerator_generateTestSection_Payload(t *testing.T) {
+	gen := New(nil)
+
+	testInfo := types.TestInfo{
+		Name: "TestCreateUser",
+		Type: types.UnitTest,
+		TestCases: []types.TestCase{
+			{
+				Name:     "Create user",
+				Input:    "{\n  \"name\": \"alice\"\n}",
+				Expected: "status: created\nid: 1",
+				Steps: []types.Step{
+					{Action: "Send request", Expected: "returns 201", Description: "Uses the admin token."},
+				},
+			},
+		},
+	}
+
+	var sb strings.Builder
+	gen.generateTestSection(&sb, testInfo)
+
+	output := sb.String()
+	assert.Contains(t, output, "**Входные данные:**\n\n```json\n{\n  \"name\": \"alice\"\n}\n```")
+	assert.Contains(t, output, "**Ожидаемый результат:**\n\n```yaml\nstatus: created\nid: 1\n```")
+	assert.Contains(t, output, "1. Send request → returns 201\n   Uses the admin token.\n")
+}
+
+func TestDetectPayloadFormat(t *testing.T) {
+	assert.Equal(t, "json", detectPayloadFormat("[1,\n2]"))
+	assert.Equal(t, "yaml", detectPayloadFormat("a: 1\nb: 2"))
+	assert.Equal(t, "text", detectPayloadFormat("first line\nsecond line"))
+}
@@ -0,0 +1,353 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// Ключи группировки для параметра group_by
+const (
+	// GroupPackage - группировка по пакетам
+	GroupPackage = "package"
+	// GroupType - группировка по типам тестов
+	GroupType = "type"
+	// GroupOwner - группировка по владельцам из CODEOWNERS или @owner
+	GroupOwner = "owner"
+	// GroupTag - группировка по тегам; тест с несколькими тегами попадает в каждую группу
+	GroupTag = "tag"
+	// GroupAuthor - группировка по авторам
+	GroupAuthor = "author"
+	// GroupPriority - группировка по приоритету @priority, от P0 к P3
+	GroupPriority = "priority"
+	// GroupSeverity - группировка по серьезности @severity, от blocker к trivial
+	GroupSeverity = "severity"
+	// GroupMetadataPrefix - префикс группировки по значению метаданных: metadata:component
+	GroupMetadataPrefix = "metadata:"
+)
+
+// GroupKeys возвращает ключи группировки без ключей метаданных
+func GroupKeys() []string {
+	return []string{GroupPackage, GroupType, GroupOwner, GroupTag, GroupAuthor, GroupPriority, GroupSeverity}
+}
+
+// ValidGroupKey проверяет ключ группировки: один из GroupKeys или
+// metadata:<ключ> с непустым ключом
+func ValidGroupKey(key string) bool {
+	if name, ok := strings.CutPrefix(key, GroupMetadataPrefix); ok {
+		return name != ""
+	}
+	for _, known := range GroupKeys() {
+		if key == known {
+			return true
+		}
+	}
+	return false
+}
+
+// section - группа тестов документа. Секции последнего уровня группировки
+// содержат тесты, остальные - вложенные секции.
+type section struct {
+	key   string
+	value string
+	title string
+	// pkg - пакет группы по пакетам
+	pkg      *types.PackageInfo
+	tests    []types.TestInfo
+	children []*section
+}
+
+// groupEntry - тест вместе с именем его пакета
+type groupEntry struct {
+	pkg  *types.PackageInfo
+	name string
+	test types.TestInfo
+}
+
+// groupKeys возвращает ключи группировки документа. Если group_by не задан,
+// используются флаги group_by_package, group_by_owner и group_by_type
+// (в порядке приоритета); без флагов тесты выводятся общим списком.
+func (g *Generator) groupKeys() []string {
+	switch {
+	case len(g.config.GroupBy) > 0:
+		return g.config.GroupBy
+	case g.config.GroupByPackage:
+		return []string{GroupPackage}
+	case g.config.GroupByOwner:
+		return []string{GroupOwner}
+	case g.config.GroupByType:
+		return []string{GroupType}
+	default:
+		return nil
+	}
+}
+
+// buildSections строит дерево секций по ключам группировки keys
+func (g *Generator) buildSections(packages map[string]*types.PackageInfo, keys []string) []*section {
+	var names []string
+	for name := range packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var entries []groupEntry
+	for _, name := range names {
+		for _, test := range packages[name].Tests {
+			entries = append(entries, groupEntry{pkg: packages[name], name: name, test: test})
+		}
+	}
+	return g.groupEntries(entries, keys)
+}
+
+// groupEntries распределяет тесты по значениям первого ключа и рекурсивно
+// группирует каждую группу по остальным ключам
+func (g *Generator) groupEntries(entries []groupEntry, keys []string) []*section {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	key := keys[0]
+	groups := make(map[string][]groupEntry)
+	for _, entry := range entries {
+		for _, value := range groupValues(key, entry) {
+			groups[value] = append(groups[value], entry)
+		}
+	}
+
+	var values []string
+	for value := range groups {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	sortGroupValues(key, values)
+	// Группа без значения идет последней
+	if _, ok := groups[""]; ok {
+		values = append(values, "")
+	}
+
+	sections := make([]*section, 0, len(values))
+	for _, value := range values {
+		group := groups[value]
+		s := &section{key: key, value: value, title: g.sectionTitle(key, value)}
+		if key == GroupPackage {
+			s.pkg = group[0].pkg
+		}
+		for _, entry := range group {
+			s.tests = append(s.tests, entry.test)
+		}
+		s.children = g.groupEntries(group, keys[1:])
+		if len(s.children) == 0 {
+			g.sortTests(s.tests, key)
+		}
+		sections = append(sections, s)
+	}
+	return sections
+}
+
+// groupValues возвращает значения ключа группировки для теста
+func groupValues(key string, entry groupEntry) []string {
+	test := entry.test
+	switch key {
+	case GroupPackage:
+		return []string{entry.name}
+	case GroupType:
+		return []string{string(test.Type)}
+	case GroupOwner:
+		if len(test.Owners) == 0 {
+			return []string{""}
+		}
+		return test.Owners
+	case GroupTag:
+		if len(test.Tags) == 0 {
+			return []string{""}
+		}
+		return test.Tags
+	case GroupAuthor:
+		return []string{test.Author}
+	case GroupPriority:
+		return []string{string(test.Priority)}
+	case GroupSeverity:
+		return []string{string(test.Severity)}
+	}
+
+	if name, ok := strings.CutPrefix(key, GroupMetadataPrefix); ok {
+		return []string{test.Metadata[name]}
+	}
+	return []string{""}
+}
+
+// sortGroupValues упорядочивает значения группировки: приоритеты и уровни
+// серьезности от высшего к низшему, остальные значения по алфавиту
+func sortGroupValues(key string, values []string) {
+	switch key {
+	case GroupPriority:
+		sort.Slice(values, func(i, j int) bool {
+			return types.Priority(values[i]).Rank() < types.Priority(values[j]).Rank()
+		})
+	case GroupSeverity:
+		sort.Slice(values, func(i, j int) bool {
+			return types.Severity(values[i]).Rank() < types.Severity(values[j]).Rank()
+		})
+	default:
+		sort.Strings(values)
+	}
+}
+
+// sectionTitle возвращает заголовок секции
+func (g *Generator) sectionTitle(key, value string) string {
+	switch key {
+	case GroupPackage:
+		return "Пакет " + value
+	case GroupType:
+		return g.getTestTypeDisplayName(types.TestType(value)) + " тесты"
+	case GroupOwner:
+		return g.getOwnerDisplayName(value)
+	case GroupTag:
+		if value == "" {
+			return "Без тегов"
+		}
+		return "Тег " + value
+	case GroupAuthor:
+		if value == "" {
+			return "Без автора"
+		}
+		return "Автор " + value
+	case GroupPriority:
+		if value == "" {
+			return "Без приоритета"
+		}
+		return "Приоритет " + value
+	case GroupSeverity:
+		if value == "" {
+			return "Без серьезности"
+		}
+		return "Серьезность " + value
+	}
+
+	name := strings.TrimPrefix(key, GroupMetadataPrefix)
+	if value == "" {
+		return "Без " + name
+	}
+	return fmt.Sprintf("%s: %s", name, value)
+}
+
+// heading возвращает маркер заголовка уровня level; уровни глубже шестого
+// выводятся шестым
+func heading(level int) string {
+	return strings.Repeat("#", min(level, 6))
+}
+
+// generateTOC генерирует оглавление: вложенный список секций и тестов
+func (g *Generator) generateTOC(sb io.StringWriter, packages map[string]*types.PackageInfo, anchors *anchorIndex) {
+	keys := g.groupKeys()
+	if len(keys) == 0 {
+		for _, test := range g.allTests(packages) {
+			sb.WriteString(fmt.Sprintf("- [%s](#%s)\n", test.Name, anchors.next(3, test.Name)))
+		}
+		sb.WriteString("\n")
+		return
+	}
+
+	for _, s := range g.buildSections(packages, keys) {
+		g.generateTOCSection(sb, s, 0, anchors)
+	}
+	sb.WriteString("\n")
+}
+
+// generateTOCSection генерирует пункт оглавления секции на глубине depth
+func (g *Generator) generateTOCSection(sb io.StringWriter, s *section, depth int, anchors *anchorIndex) {
+	indent := strings.Repeat("  ", depth)
+	level := depth + 2
+	sb.WriteString(fmt.Sprintf("%s- [%s](#%s)\n", indent, s.title, anchors.next(level, s.title)))
+
+	if len(s.children) > 0 {
+		for _, child := range s.children {
+			g.generateTOCSection(sb, child, depth+1, anchors)
+		}
+		return
+	}
+	for _, test := range s.tests {
+		sb.WriteString(fmt.Sprintf("%s  - [%s](#%s)\n", indent, test.Name, anchors.next(level+1, test.Name)))
+	}
+}
+
+// generateContent генерирует основной контент: секции групп или общий список тестов
+func (g *Generator) generateContent(sb io.StringWriter, packages map[string]*types.PackageInfo) {
+	keys := g.groupKeys()
+	if len(keys) == 0 {
+		sb.WriteString("## Тесты\n\n")
+		for _, test := range g.allTests(packages) {
+			g.generateTestSection(sb, test)
+		}
+		return
+	}
+
+	for _, s := range g.buildSections(packages, keys) {
+		g.generateSection(sb, s, 2)
+	}
+}
+
+// generateSection генерирует секцию группы с заголовком уровня level
+func (g *Generator) generateSection(sb io.StringWriter, s *section, level int) {
+	sb.WriteString(fmt.Sprintf("%s %s\n\n", heading(level), s.title))
+
+	switch s.key {
+	case GroupPackage:
+		if s.pkg.Description != "" {
+			sb.WriteString(fmt.Sprintf("%s\n\n", s.pkg.Description))
+		}
+		sb.WriteString(fmt.Sprintf("**Путь:** `%s`\n\n", s.pkg.Path))
+	case GroupOwner:
+		g.generateOwnerSummary(sb, s.tests)
+	}
+
+	if len(s.children) > 0 {
+		for _, child := range s.children {
+			g.generateSection(sb, child, level+1)
+		}
+		return
+	}
+	for _, test := range s.tests {
+		g.generateTestSectionAt(sb, test, level+1)
+	}
+}
+
+// generateOwnerSummary генерирует сводку группы владельца: количество тестов
+// и список пропущенных
+func (g *Generator) generateOwnerSummary(sb io.StringWriter, tests []types.TestInfo) {
+	var skipped []types.TestInfo
+	for _, test := range tests {
+		if test.Skipped {
+			skipped = append(skipped, test)
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("**Всего тестов:** %d, **пропущено:** %d\n\n", len(tests), len(skipped)))
+	if len(skipped) == 0 {
+		return
+	}
+
+	sb.WriteString("**Пропущенные тесты:**\n\n")
+	for _, test := range skipped {
+		if test.SkipReason != "" {
+			sb.WriteString(fmt.Sprintf("- `%s` — %s\n", test.Name, test.SkipReason))
+		} else {
+			sb.WriteString(fmt.Sprintf("- `%s`\n", test.Name))
+		}
+	}
+	sb.WriteString("\n")
+}
+
+// allTests возвращает тесты всех пакетов для общего списка
+func (g *Generator) allTests(packages map[string]*types.PackageInfo) []types.TestInfo {
+	var tests []types.TestInfo
+	for _, s := range g.buildSections(packages, []string{GroupPackage}) {
+		tests = append(tests, s.tests...)
+	}
+	g.sortTests(tests, "")
+	return tests
+}
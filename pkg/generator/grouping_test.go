@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// groupingPackages возвращает пакеты с тестами разных типов, тегов и метаданных
+func groupingPackages() map[string]*types.PackageInfo {
+	return map[string]*types.PackageInfo{
+		"billing": {Name: "billing", Path: "internal/billing", Tests: []types.TestInfo{
+			{Name: "TestRefund", Type: types.UnitTest, Tags: []string{"money"}, Metadata: map[string]string{"component": "refunds"}},
+			{Name: "TestCharge", Type: types.UnitTest, Tags: []string{"money", "api"}, Metadata: map[string]string{"component": "payments"}},
+			{Name: "TestInvoiceE2E", Type: types.E2ETest},
+		}},
+		"api": {Name: "api", Path: "internal/api", Tests: []types.TestInfo{
+			{Name: "TestRoutes", Type: types.UnitTest, Tags: []string{"api"}, Author: "Анна"},
+		}},
+	}
+}
+
+func TestGenerator_groupKeys(t *testing.T) {
+	assert.Nil(t, New(&types.Config{}).groupKeys())
+	assert.Equal(t, []string{GroupType}, New(nil).groupKeys())
+	assert.Equal(t, []string{GroupPackage}, New(&types.Config{GroupByPackage: true, GroupByType: true}).groupKeys())
+	assert.Equal(t, []string{GroupOwner}, New(&types.Config{GroupByOwner: true, GroupByType: true}).groupKeys())
+	// group_by заменяет флаги
+	assert.Equal(t, []string{GroupTag, GroupType},
+		New(&types.Config{GroupBy: []string{GroupTag, GroupType}, GroupByPackage: true}).groupKeys())
+}
+
+func TestGenerator_buildSections_Nested(t *testing.T) {
+	sections := New(nil).buildSections(groupingPackages(), []string{GroupPackage, GroupType})
+
+	require.Len(t, sections, 2)
+	assert.Equal(t, "Пакет api", sections[0].title)
+	billing := sections[1]
+	assert.Equal(t, "Пакет billing", billing.title)
+	assert.Equal(t, "internal/billing", billing.pkg.Path)
+	assert.Len(t, billing.tests, 3)
+
+	require.Len(t, billing.children, 2)
+	assert.Equal(t, "E2E тесты", billing.children[0].title)
+	assert.Equal(t, "Модульные тесты", billing.children[1].title)
+	// Внутри группы по типу тесты отсортированы по имени
+	assert.Equal(t, "TestCharge", billing.children[1].tests[0].Name)
+	assert.Equal(t, "TestRefund", billing.children[1].tests[1].Name)
+}
+
+func TestGenerator_buildSections_TagsAndMetadata(t *testing.T) {
+	gen := New(nil)
+
+	sections := gen.buildSections(groupingPackages(), []string{GroupTag})
+	var titles []string
+	for _, s := range sections {
+		titles = append(titles, s.title)
+	}
+	// Тест с несколькими тегами попадает в каждую группу, тесты без тегов - в последнюю
+	assert.Equal(t, []string{"Тег api", "Тег money", "Без тегов"}, titles)
+	assert.Len(t, sections[0].tests, 2)
+	assert.Len(t, sections[1].tests, 2)
+
+	sections = gen.buildSections(groupingPackages(), []string{"metadata:component"})
+	titles = nil
+	for _, s := range sections {
+		titles = append(titles, s.title)
+	}
+	assert.Equal(t, []string{"component: payments", "component: refunds", "Без component"}, titles)
+
+	sections = gen.buildSections(groupingPackages(), []string{GroupAuthor})
+	assert.Equal(t, "Автор Анна", sections[0].title)
+	assert.Equal(t, "Без автора", sections[1].title)
+}
+
+func TestGenerator_GenerateMarkdown_NestedGrouping(t *testing.T) {
+	result := &types.ParseResult{Packages: groupingPackages()}
+	result.CalculateStats()
+
+	doc := New(&types.Config{GroupBy: []string{GroupPackage, GroupType}}).GenerateMarkdown(result)
+
+	assert.Contains(t, doc, "- [Пакет billing](#пакет-billing)\n"+
+		"  - [E2E тесты](#e2e-тесты)\n"+
+		"    - [TestInvoiceE2E](#testinvoicee2e)\n"+
+		"  - [Модульные тесты](#модульные-тесты-1)\n"+
+		"    - [TestCharge](#testcharge)\n")
+	assert.Contains(t, doc, "## Пакет billing\n\n**Путь:** `internal/billing`\n\n### E2E тесты\n\n#### TestInvoiceE2E\n")
+	assert.Contains(t, doc, "### Модульные тесты\n\n#### TestCharge\n")
+
+	// Тесты глубже шестого уровня выводятся заголовками шестого уровня
+	doc = New(&types.Config{GroupBy: []string{GroupPackage, GroupType, GroupTag, GroupAuthor, "metadata:component"}}).GenerateMarkdown(result)
+	assert.Contains(t, doc, "###### TestCharge\n")
+	assert.False(t, strings.Contains(doc, "####### "))
+}
+
+func TestValidGroupKey(t *testing.T) {
+	for _, key := range GroupKeys() {
+		assert.True(t, ValidGroupKey(key), key)
+	}
+	assert.True(t, ValidGroupKey("metadata:component"))
+	assert.False(t, ValidGroupKey("metadata:"))
+	assert.False(t, ValidGroupKey("component"))
+}
+
+func TestGenerator_buildSections_PriorityAndSeverity(t *testing.T) {
+	packages := map[string]*types.PackageInfo{
+		"billing": {Name: "billing", Tests: []types.TestInfo{
+			{Name: "TestReport", Priority: types.P3, Severity: types.SeverityMinor},
+			{Name: "TestCharge", Priority: types.P0, Severity: types.SeverityBlocker},
+			{Name: "TestExport"},
+			{Name: "TestRefund", Priority: types.P1, Severity: types.SeverityMinor},
+		}},
+	}
+	gen := New(nil)
+
+	var titles []string
+	for _, s := range gen.buildSections(packages, []string{GroupPriority}) {
+		titles = append(titles, s.title)
+	}
+	// Приоритеты идут от P0 к P3, а не по алфавиту
+	assert.Equal(t, []string{"Приоритет P0", "Приоритет P1", "Приоритет P3", "Без приоритета"}, titles)
+
+	titles = nil
+	for _, s := range gen.buildSections(packages, []string{GroupSeverity}) {
+		titles = append(titles, s.title)
+	}
+	assert.Equal(t, []string{"Серьезность blocker", "Серьезность minor", "Без серьезности"}, titles)
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// generateQuality генерирует раздел качества документации: покрытие
+// критериев, оценки проекта и пакетов и список худших тестов
+func (g *Generator) generateQuality(sb io.StringWriter, stats *types.Statistics) {
+	if stats.TotalTests == 0 {
+		return
+	}
+	quality := stats.Quality
+
+	sb.WriteString("### Качество документации\n\n")
+	sb.WriteString(fmt.Sprintf("**Оценка проекта:** %.1f из 100\n\n", quality.Score))
+
+	sb.WriteString("| Критерий | Вес | Тестов | Доля |\n")
+	sb.WriteString("|----------|-----|--------|------|\n")
+	for _, c := range types.QualityCriteria {
+		sb.WriteString(fmt.Sprintf("| %s | %.0f | %d | %.1f%% |\n",
+			g.getCriterionDisplayName(c.Criterion), c.Weight,
+			quality.Coverage[c.Criterion], stats.CoveragePercent(c.Criterion)))
+	}
+	sb.WriteString("\n")
+
+	if len(quality.Packages) > 1 {
+		packageNames := make([]string, 0, len(quality.Packages))
+		for name := range quality.Packages {
+			packageNames = append(packageNames, name)
+		}
+		sort.Slice(packageNames, func(i, j int) bool {
+			a, b := quality.Packages[packageNames[i]], quality.Packages[packageNames[j]]
+			if a != b {
+				return a < b
+			}
+			return packageNames[i] < packageNames[j]
+		})
+
+		sb.WriteString("**Оценка по пакетам:**\n\n")
+		for _, name := range packageNames {
+			sb.WriteString(fmt.Sprintf("- `%s`: %.1f\n", name, quality.Packages[name]))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(quality.Worst) > 0 {
+		sb.WriteString("**Хуже всего документированы:**\n\n")
+		sb.WriteString("| Тест | Пакет | Оценка | Не хватает |\n")
+		sb.WriteString("|------|-------|--------|------------|\n")
+		for _, test := range quality.Worst {
+			missing := make([]string, len(test.Missing))
+			for i, criterion := range test.Missing {
+				missing[i] = g.getCriterionDisplayName(criterion)
+			}
+			sb.WriteString(fmt.Sprintf("| `%s` | `%s` | %.0f | %s |\n",
+				test.Name, test.Package, test.Score, strings.Join(missing, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// getCriterionDisplayName возвращает отображаемое имя критерия качества
+func (g *Generator) getCriterionDisplayName(criterion types.QualityCriterion) string {
+	switch criterion {
+	case types.CriterionDescription:
+		return "Описание"
+	case types.CriterionType:
+		return "Тип (@type)"
+	case types.CriterionAuthor:
+		return "Автор"
+	case types.CriterionTestCases:
+		return "Тест-кейсы"
+	case types.CriterionSteps:
+		return "Шаги тест-кейсов"
+	default:
+		return string(criterion)
+	}
+}
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/seblex/testdoc/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_generateQuality(t *testing.T) {
+	gen := New(nil)
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"auth": {Tests: []types.TestInfo{
+				{Name: "TestLogin", Package: "auth", Description: "Вход", ExplicitType: true},
+			}},
+			"api": {Tests: []types.TestInfo{
+				{Name: "TestHandler", Package: "api"},
+			}},
+		},
+	}
+	result.CalculateStats()
+
+	var sb strings.Builder
+	gen.generateQuality(&sb, &result.Stats)
+
+	output := sb.String()
+	assert.Contains(t, output, "### Качество документации")
+	assert.Contains(t, output, "**Оценка проекта:** 25.0 из 100")
+	assert.Contains(t, output, "| Описание | 35 | 1 | 50.0% |")
+	assert.Contains(t, output, "| Тип (@type) | 15 | 1 | 50.0% |")
+	assert.Contains(t, output, "- `api`: 0.0\n- `auth`: 50.0\n")
+	assert.Contains(t, output, "| `TestHandler` | `api` | 0 | Описание, Тип (@type), Автор, Тест-кейсы, Шаги тест-кейсов |")
+	assert.True(t, strings.Index(output, "`TestHandler`") < strings.Index(output, "`TestLogin`"))
+}
+
+func TestGenerator_generateQuality_Empty(t *testing.T) {
+	var sb strings.Builder
+	New(nil).generateQuality(&sb, &types.Statistics{})
+	assert.Empty(t, sb.String())
+}
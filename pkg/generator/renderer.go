@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// Встроенные форматы вывода
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+)
+
+// Renderer записывает документацию тестов в w в определенном формате
+type Renderer interface {
+	Render(w io.Writer, result *types.ParseResult) error
+}
+
+// RendererFactory создает рендерер с заданной конфигурацией
+type RendererFactory func(config *types.Config) Renderer
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = make(map[string]RendererFactory)
+)
+
+func init() {
+	Register(FormatMarkdown, func(config *types.Config) Renderer {
+		return New(config)
+	})
+	Register(FormatJSON, func(config *types.Config) Renderer {
+		return &JSONRenderer{}
+	})
+}
+
+// Register регистрирует формат вывода. Форматы из других пакетов
+// подключаются вызовом Register в их функции init.
+// Повторная регистрация формата или nil вызывают панику.
+func Register(format string, factory RendererFactory) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+
+	if factory == nil {
+		panic("generator: Register factory is nil")
+	}
+	if _, exists := renderers[format]; exists {
+		panic("generator: Register called twice for format " + format)
+	}
+	renderers[format] = factory
+}
+
+// Lookup возвращает фабрику рендерера для формата
+func Lookup(format string) (RendererFactory, bool) {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+
+	factory, ok := renderers[format]
+	return factory, ok
+}
+
+// Formats возвращает отсортированный список зарегистрированных форматов
+func Formats() []string {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+
+	formats := make([]string, 0, len(renderers))
+	for format := range renderers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// NewRenderer создает рендерер для формата. Пустой формат означает Markdown.
+func NewRenderer(format string, config *types.Config) (Renderer, error) {
+	if format == "" {
+		format = FormatMarkdown
+	}
+
+	factory, ok := Lookup(format)
+	if !ok {
+		return nil, fmt.Errorf("неизвестный формат вывода %q (доступны: %s)", format, strings.Join(Formats(), ", "))
+	}
+	return factory(config), nil
+}
+
+// Render записывает Markdown документацию в w
+func (g *Generator) Render(w io.Writer, result *types.ParseResult) error {
+	return g.WriteMarkdown(w, result)
+}
+
+// JSONRenderer записывает результат парсинга в формате JSON
+type JSONRenderer struct{}
+
+// Render записывает результат парсинга в w в виде JSON с отступами
+func (r *JSONRenderer) Render(w io.Writer, result *types.ParseResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
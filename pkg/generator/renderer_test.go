@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+type wikiRenderer struct {
+	title string
+}
+
+func (r *wikiRenderer) Render(w io.Writer, result *types.ParseResult) error {
+	_, err := io.WriteString(w, "h1. "+r.title)
+	return err
+}
+
+func TestRegister(t *testing.T) {
+	Register("wiki-test", func(config *types.Config) Renderer {
+		return &wikiRenderer{title: config.Title}
+	})
+
+	assert.Contains(t, Formats(), "wiki-test")
+
+	renderer, err := NewRenderer("wiki-test", &types.Config{Title: "Docs"})
+	require.NoError(t, err)
+
+	var sb strings.Builder
+	require.NoError(t, renderer.Render(&sb, &types.ParseResult{}))
+	assert.Equal(t, "h1. Docs", sb.String())
+
+	assert.Panics(t, func() {
+		Register("wiki-test", func(config *types.Config) Renderer { return nil })
+	})
+	assert.Panics(t, func() {
+		Register("nil-test", nil)
+	})
+}
+
+func TestNewRenderer(t *testing.T) {
+	renderer, err := NewRenderer("", nil)
+	require.NoError(t, err)
+	assert.IsType(t, &Generator{}, renderer)
+
+	renderer, err = NewRenderer(FormatJSON, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &JSONRenderer{}, renderer)
+
+	_, err = NewRenderer("pdf", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pdf")
+}
+
+func TestFormats(t *testing.T) {
+	formats := Formats()
+	assert.Contains(t, formats, FormatMarkdown)
+	assert.Contains(t, formats, FormatJSON)
+	assert.IsIncreasing(t, formats)
+}
+
+func TestJSONRenderer_Render(t *testing.T) {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"example": {Name: "example", Tests: []types.TestInfo{{Name: "TestA", Type: types.UnitTest}}},
+		},
+	}
+	result.CalculateStats()
+
+	var sb strings.Builder
+	require.NoError(t, (&JSONRenderer{}).Render(&sb, result))
+
+	var decoded types.ParseResult
+	require.NoError(t, json.Unmarshal([]byte(sb.String()), &decoded))
+	assert.Equal(t, "TestA", decoded.Packages["example"].Tests[0].Name)
+	assert.Equal(t, 1, decoded.Stats.TotalTests)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestGenerator_Render_WriterError(t *testing.T) {
+	err := New(nil).Render(failingWriter{}, &types.ParseResult{Packages: map[string]*types.PackageInfo{}})
+	assert.EqualError(t, err, "disk full")
+}
@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// generateRisk генерирует раздел рисков релиза: пропущенные тесты P0, тесты
+// P0 без шагов и распределение приоритетов по пакетам. Раздел выводится,
+// только если хотя бы у одного теста задан @priority.
+func (g *Generator) generateRisk(sb io.StringWriter, report types.RiskReport) {
+	if report.Empty() {
+		return
+	}
+
+	sb.WriteString("## Риски релиза\n\n")
+
+	g.generateRiskList(sb, "Пропущенные тесты P0", report.SkippedCritical, func(test types.TestInfo) string {
+		return test.SkipReason
+	})
+	g.generateRiskList(sb, "Тесты P0 без шагов", report.CriticalWithoutSteps, nil)
+
+	sb.WriteString("**Приоритеты по пакетам:**\n\n")
+	sb.WriteString("| Пакет |")
+	for _, priority := range types.Priorities() {
+		sb.WriteString(fmt.Sprintf(" %s |", priority))
+	}
+	sb.WriteString(" Без приоритета |\n")
+	sb.WriteString("|-------|" + strings.Repeat("----|", len(types.Priorities())) + "----------------|\n")
+	for _, pkg := range report.Packages {
+		sb.WriteString(fmt.Sprintf("| `%s` |", pkg.Package))
+		for _, priority := range types.Priorities() {
+			sb.WriteString(fmt.Sprintf(" %d |", pkg.Counts[priority]))
+		}
+		sb.WriteString(fmt.Sprintf(" %d |\n", pkg.Unprioritized))
+	}
+	sb.WriteString("\n")
+}
+
+// generateRiskList генерирует список тестов риска с заголовком label;
+// detail возвращает пояснение к тесту
+func (g *Generator) generateRiskList(sb io.StringWriter, label string, tests []types.TestInfo, detail func(types.TestInfo) string) {
+	if len(tests) == 0 {
+		sb.WriteString(fmt.Sprintf("**%s:** нет ✅\n\n", label))
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("**%s:** %d ⚠️\n\n", label, len(tests)))
+	for _, test := range tests {
+		line := fmt.Sprintf("- `%s` (`%s:%d`)", test.Name, test.File, test.Line)
+		if detail != nil {
+			if text := detail(test); text != "" {
+				line += " — " + text
+			}
+		}
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString("\n")
+}
+
+// formatPriority возвращает бейдж приоритета
+func (g *Generator) formatPriority(priority types.Priority) string {
+	switch priority {
+	case types.P0:
+		return "🔴 P0"
+	case types.P1:
+		return "🟠 P1"
+	case types.P2:
+		return "🟡 P2"
+	case types.P3:
+		return "🟢 P3"
+	default:
+		return string(priority)
+	}
+}
+
+// formatSeverity возвращает бейдж серьезности
+func (g *Generator) formatSeverity(severity types.Severity) string {
+	switch severity {
+	case types.SeverityBlocker:
+		return "⛔ Блокирующая"
+	case types.SeverityCritical:
+		return "🔴 Критическая"
+	case types.SeverityMajor:
+		return "🟠 Значительная"
+	case types.SeverityMinor:
+		return "🟡 Незначительная"
+	case types.SeverityTrivial:
+		return "⚪ Тривиальная"
+	default:
+		return string(severity)
+	}
+}
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func TestGenerator_generateRisk(t *testing.T) {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"billing": {Name: "billing", Path: "internal/billing", Tests: []types.TestInfo{
+				{Name: "TestCharge", File: "charge_test.go", Line: 10, Priority: types.P0,
+					Skipped: true, SkipReason: "нестабилен"},
+				{Name: "TestReport", File: "report_test.go", Line: 5, Priority: types.P3},
+				{Name: "TestExport", File: "export_test.go", Line: 7},
+			},
+			},
+		},
+	}
+	result.CalculateStats()
+
+	doc := New(nil).GenerateMarkdown(result)
+	assert.Contains(t, doc, "## Риски релиза\n\n"+
+		"**Пропущенные тесты P0:** 1 ⚠️\n\n"+
+		"- `TestCharge` (`charge_test.go:10`) — нестабилен\n\n"+
+		"**Тесты P0 без шагов:** 1 ⚠️\n\n"+
+		"- `TestCharge` (`charge_test.go:10`)\n\n")
+	assert.Contains(t, doc, "| Пакет | P0 | P1 | P2 | P3 | Без приоритета |\n"+
+		"|-------|----|----|----|----|----------------|\n"+
+		"| `billing` | 1 | 0 | 0 | 1 | 1 |\n")
+	assert.Contains(t, doc, "| **Приоритет** | 🔴 P0 |")
+
+	// Без приоритетов раздел не выводится
+	result.Packages["billing"].Tests = result.Packages["billing"].Tests[2:]
+	assert.NotContains(t, New(nil).GenerateMarkdown(result), "Риски релиза")
+}
+
+func TestGenerator_generateTestSection_Severity(t *testing.T) {
+	testInfo := types.TestInfo{Name: "TestCharge", Priority: types.P1, Severity: types.SeverityBlocker}
+
+	var sb strings.Builder
+	New(nil).generateTestSection(&sb, testInfo)
+
+	assert.Contains(t, sb.String(), "| **Приоритет** | 🟠 P1 |\n| **Серьезность** | ⛔ Блокирующая |\n")
+}
@@ -0,0 +1,37 @@
+package generator
+
+import (
+	"io"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// WriteTestSection записывает Markdown секцию одного теста в w.
+// Используется генераторами, которые собирают документ из отдельных частей.
+func (g *Generator) WriteTestSection(w io.Writer, test types.TestInfo) error {
+	sb := newDocWriter(w)
+	g.generateTestSection(sb, test)
+	return sb.Flush()
+}
+
+// WriteStatistics записывает Markdown статистику тестов в w
+func (g *Generator) WriteStatistics(w io.Writer, stats *types.Statistics) error {
+	sb := newDocWriter(w)
+	g.generateStatistics(sb, stats)
+	return sb.Flush()
+}
+
+// TestTypeDisplayName возвращает отображаемое имя типа теста
+func (g *Generator) TestTypeDisplayName(testType types.TestType) string {
+	return g.getTestTypeDisplayName(testType)
+}
+
+// PriorityBadge возвращает бейдж приоритета теста, например "🔴 P0"
+func (g *Generator) PriorityBadge(priority types.Priority) string {
+	return g.formatPriority(priority)
+}
+
+// SeverityBadge возвращает бейдж серьезности теста, например "⛔ Блокирующая"
+func (g *Generator) SeverityBadge(severity types.Severity) string {
+	return g.formatSeverity(severity)
+}
@@ -0,0 +1,164 @@
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// Ключи сортировки тестов для параметра sort_by
+const (
+	// SortName - по имени теста
+	SortName = "name"
+	// SortSource - по файлу и строке: порядок тестов в исходном коде
+	SortSource = "source"
+	// SortCreated - по дате @created
+	SortCreated = "created"
+	// SortUpdated - по дате @updated
+	SortUpdated = "updated"
+	// SortAuthor - по автору
+	SortAuthor = "author"
+	// SortStatus - по статусу: активные, условно пропускаемые, пропущенные
+	SortStatus = "status"
+	// SortDuration - по длительности последнего запуска (нужны результаты go test -json)
+	SortDuration = "duration"
+	// SortPriority - по аннотации @priority: P0 раньше P3
+	SortPriority = "priority"
+	// SortSeverity - по аннотации @severity: blocker раньше trivial
+	SortSeverity = "severity"
+)
+
+// Направления сортировки для параметра sort_order
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// SortKeys возвращает ключи сортировки тестов
+func SortKeys() []string {
+	return []string{SortName, SortSource, SortCreated, SortUpdated, SortAuthor, SortStatus, SortDuration, SortPriority, SortSeverity}
+}
+
+// SortOrders возвращает направления сортировки
+func SortOrders() []string {
+	return []string{SortAsc, SortDesc}
+}
+
+// SortTests упорядочивает тесты по ключу by в направлении order (asc по
+// умолчанию). Тесты без значения ключа (без даты, автора, результата запуска
+// приоритета или серьезности) идут последними при любом направлении. Тесты с равными
+// значениями упорядочиваются по имени и положению в исходном коде.
+func SortTests(tests []types.TestInfo, by, order string) {
+	desc := order == SortDesc
+	sort.SliceStable(tests, func(i, j int) bool {
+		a, b := tests[i], tests[j]
+
+		missingA, missingB := missingSortValue(by, a), missingSortValue(by, b)
+		if missingA != missingB {
+			return missingB
+		}
+
+		if c := compareTests(by, a, b); c != 0 {
+			if desc {
+				return c > 0
+			}
+			return c < 0
+		}
+
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c < 0
+		}
+		return compareSource(a, b) < 0
+	})
+}
+
+// missingSortValue проверяет, что у теста нет значения ключа сортировки
+func missingSortValue(by string, test types.TestInfo) bool {
+	switch by {
+	case SortCreated:
+		return test.Created.IsZero()
+	case SortUpdated:
+		return test.Updated.IsZero()
+	case SortAuthor:
+		return test.Author == ""
+	case SortDuration:
+		return test.Run == nil
+	case SortPriority:
+		return test.Priority == ""
+	case SortSeverity:
+		return test.Severity == ""
+	default:
+		return false
+	}
+}
+
+// compareTests сравнивает тесты по ключу сортировки
+func compareTests(by string, a, b types.TestInfo) int {
+	switch by {
+	case SortSource:
+		return compareSource(a, b)
+	case SortCreated:
+		return a.Created.Compare(b.Created)
+	case SortUpdated:
+		return a.Updated.Compare(b.Updated)
+	case SortAuthor:
+		return strings.Compare(a.Author, b.Author)
+	case SortStatus:
+		return statusRank(a) - statusRank(b)
+	case SortDuration:
+		return compareInts(int64(a.Run.Elapsed), int64(b.Run.Elapsed))
+	case SortPriority:
+		return a.Priority.Rank() - b.Priority.Rank()
+	case SortSeverity:
+		return a.Severity.Rank() - b.Severity.Rank()
+	default:
+		return strings.Compare(a.Name, b.Name)
+	}
+}
+
+// compareSource сравнивает положение тестов: файл, затем строка
+func compareSource(a, b types.TestInfo) int {
+	if c := strings.Compare(a.File, b.File); c != 0 {
+		return c
+	}
+	return a.Line - b.Line
+}
+
+// compareInts сравнивает числа
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// statusRank возвращает порядок статуса теста: активный, условно пропускаемый, пропущенный
+func statusRank(test types.TestInfo) int {
+	switch {
+	case test.Skipped:
+		return 2
+	case len(test.ConditionalSkips()) > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// sortTests упорядочивает тесты секции группы key (пустой ключ - общий
+// список). Без sort_by сохраняется прежний порядок: в группе по пакетам тесты
+// идут в порядке обхода, в остальных случаях - по имени.
+func (g *Generator) sortTests(tests []types.TestInfo, key string) {
+	by := g.config.SortBy
+	if by == "" {
+		if key == GroupPackage {
+			return
+		}
+		by = SortName
+	}
+	SortTests(tests, by, g.config.SortOrder)
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// sortingTests возвращает тесты с разными значениями ключей сортировки
+func sortingTests() []types.TestInfo {
+	date := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	return []types.TestInfo{
+		{Name: "TestC", File: "a_test.go", Line: 30, Author: "Борис", Created: date("2024-03-01"),
+			Priority: types.P2, Severity: types.SeverityMinor, Run: &types.RunResult{Outcome: "pass", Elapsed: 2 * time.Second}},
+		{Name: "TestA", File: "b_test.go", Line: 10, Skipped: true,
+			Priority: types.P0, Severity: types.SeverityMajor},
+		{Name: "TestB", File: "a_test.go", Line: 50, Author: "Анна", Created: date("2023-01-15"),
+			Skips: []types.SkipInfo{{Kind: types.SkipShort}}, Run: &types.RunResult{Outcome: "pass", Elapsed: 5 * time.Second}},
+		{Name: "TestD", File: "a_test.go", Line: 5, Priority: types.P1, Severity: types.SeverityBlocker,
+			Run: &types.RunResult{Outcome: "fail", Elapsed: time.Second}},
+	}
+}
+
+// testNames возвращает имена тестов по порядку
+func testNames(tests []types.TestInfo) []string {
+	names := make([]string, len(tests))
+	for i, test := range tests {
+		names[i] = test.Name
+	}
+	return names
+}
+
+func TestSortTests(t *testing.T) {
+	tests := []struct {
+		by, order string
+		expected  []string
+	}{
+		{SortName, SortAsc, []string{"TestA", "TestB", "TestC", "TestD"}},
+		{SortName, SortDesc, []string{"TestD", "TestC", "TestB", "TestA"}},
+		{SortSource, "", []string{"TestD", "TestC", "TestB", "TestA"}},
+		{SortCreated, SortAsc, []string{"TestB", "TestC", "TestA", "TestD"}},
+		// Тесты без значения остаются последними и при обратном порядке
+		{SortCreated, SortDesc, []string{"TestC", "TestB", "TestA", "TestD"}},
+		{SortAuthor, "", []string{"TestB", "TestC", "TestA", "TestD"}},
+		{SortStatus, "", []string{"TestC", "TestD", "TestB", "TestA"}},
+		{SortDuration, SortDesc, []string{"TestB", "TestC", "TestD", "TestA"}},
+		{SortPriority, "", []string{"TestA", "TestD", "TestC", "TestB"}},
+		{SortPriority, SortDesc, []string{"TestC", "TestD", "TestA", "TestB"}},
+		{SortSeverity, "", []string{"TestD", "TestA", "TestC", "TestB"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by+"_"+tt.order, func(t *testing.T) {
+			sorted := sortingTests()
+			SortTests(sorted, tt.by, tt.order)
+			assert.Equal(t, tt.expected, testNames(sorted))
+		})
+	}
+}
+
+func TestGenerator_sortTests(t *testing.T) {
+	packages := map[string]*types.PackageInfo{"pkg": {Name: "pkg", Tests: sortingTests()}}
+
+	// Без sort_by раздел пакета сохраняет порядок обхода
+	sections := New(&types.Config{GroupByPackage: true}).buildSections(packages, []string{GroupPackage})
+	assert.Equal(t, []string{"TestC", "TestA", "TestB", "TestD"}, testNames(sections[0].tests))
+
+	sections = New(&types.Config{GroupByPackage: true, SortBy: SortSource}).buildSections(packages, []string{GroupPackage})
+	assert.Equal(t, []string{"TestD", "TestC", "TestB", "TestA"}, testNames(sections[0].tests))
+
+	// Общий список без sort_by упорядочен по имени
+	assert.Equal(t, []string{"TestA", "TestB", "TestC", "TestD"}, testNames(New(&types.Config{}).allTests(packages)))
+
+	doc := New(&types.Config{SortBy: SortDuration, SortOrder: SortDesc}).GenerateMarkdown(&types.ParseResult{Packages: packages})
+	assert.True(t, strings.Index(doc, "### TestB") < strings.Index(doc, "### TestC"))
+	assert.Contains(t, doc, "| **Последний запуск** | ❌ Упал за 1s |")
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// generateTraceability генерирует матрицу трассируемости требований
+func (g *Generator) generateTraceability(sb io.StringWriter, result *types.ParseResult) {
+	matrix := result.Traceability(g.config.Requirements)
+
+	sb.WriteString("## Матрица трассируемости\n\n")
+	if len(matrix) == 0 {
+		sb.WriteString("Требования не указаны.\n\n")
+		return
+	}
+
+	covered, missing := 0, 0
+	for _, entry := range matrix {
+		switch entry.Status {
+		case types.TraceCovered:
+			covered++
+		case types.TraceMissing:
+			missing++
+		}
+	}
+	sb.WriteString(fmt.Sprintf("- **Требований:** %d\n", len(matrix)))
+	sb.WriteString(fmt.Sprintf("- **Покрыто активными тестами:** %d\n", covered))
+	sb.WriteString(fmt.Sprintf("- **Без тестов:** %d\n\n", missing))
+
+	sb.WriteString("| Требование | Тесты | Статус |\n")
+	sb.WriteString("|------------|-------|--------|\n")
+	for _, entry := range matrix {
+		tests := make([]string, len(entry.Tests))
+		for i, test := range entry.Tests {
+			tests[i] = fmt.Sprintf("`%s.%s`", test.Package, test.Name)
+			if test.Skipped {
+				tests[i] += " ⏭️"
+			}
+		}
+		if len(tests) == 0 {
+			tests = []string{"—"}
+		}
+
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
+			g.formatLink(types.LinkRequirement, entry.Requirement),
+			strings.Join(tests, "<br>"),
+			g.getTraceStatusDisplayName(entry.Status)))
+	}
+	sb.WriteString("\n")
+}
+
+// formatLinks форматирует список идентификаторов трекера через запятую
+func (g *Generator) formatLinks(kind string, ids []string) string {
+	links := make([]string, len(ids))
+	for i, id := range ids {
+		links[i] = g.formatLink(kind, id)
+	}
+	return strings.Join(links, ", ")
+}
+
+// formatLink форматирует идентификатор как ссылку по шаблону из link_templates
+func (g *Generator) formatLink(kind, id string) string {
+	if url := g.config.ExpandLink(kind, id); url != "" {
+		return fmt.Sprintf("[%s](%s)", id, url)
+	}
+	return fmt.Sprintf("`%s`", id)
+}
+
+// getTraceStatusDisplayName возвращает отображаемое имя статуса трассируемости
+func (g *Generator) getTraceStatusDisplayName(status types.TraceStatus) string {
+	switch status {
+	case types.TraceCovered:
+		return "✅ Покрыто"
+	case types.TraceSkipped:
+		return "⏭️ Только пропущенные тесты"
+	case types.TraceMissing:
+		return "❌ Нет тестов"
+	default:
+		return string(status)
+	}
+}
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func TestGenerator_generateTraceability(t *testing.T) {
+	config := &types.Config{
+		Traceability: true,
+		Requirements: []string{"REQ-3"},
+		LinkTemplates: map[string]string{
+			types.LinkRequirement: "https://tracker.example.com/{id}",
+		},
+	}
+	gen := New(config)
+
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"auth": {
+				Tests: []types.TestInfo{
+					{Name: "TestLogin", Package: "auth", Requirements: []string{"REQ-1"}},
+					{Name: "TestLogout", Package: "auth", Requirements: []string{"REQ-2"}, Skipped: true},
+				},
+			},
+		},
+	}
+
+	var sb strings.Builder
+	gen.generateTraceability(&sb, result)
+
+	output := sb.String()
+	assert.Contains(t, output, "## Матрица трассируемости")
+	assert.Contains(t, output, "- **Требований:** 3")
+	assert.Contains(t, output, "- **Без тестов:** 1")
+	assert.Contains(t, output, "| [REQ-1](https://tracker.example.com/REQ-1) | `auth.TestLogin` | ✅ Покрыто |")
+	assert.Contains(t, output, "| [REQ-2](https://tracker.example.com/REQ-2) | `auth.TestLogout` ⏭️ | ⏭️ Только пропущенные тесты |")
+	assert.Contains(t, output, "| [REQ-3](https://tracker.example.com/REQ-3) | — | ❌ Нет тестов |")
+}
+
+func TestGenerator_generateTestSection_Links(t *testing.T) {
+	gen := New(&types.Config{
+		LinkTemplates: map[string]string{
+			types.LinkIssue: "https://bugs.example.com/{id}",
+		},
+	})
+
+	var sb strings.Builder
+	gen.generateTestSection(&sb, types.TestInfo{
+		Name:         "TestLogin",
+		Type:         types.UnitTest,
+		Requirements: []string{"REQ-1", "REQ-2"},
+		Issues:       []string{"BUG-7"},
+		Stories:      []string{"STORY-1"},
+	})
+
+	output := sb.String()
+	assert.Contains(t, output, "| **Требования** | `REQ-1`, `REQ-2` |")
+	assert.Contains(t, output, "| **Задачи** | [BUG-7](https://bugs.example.com/BUG-7) |")
+	assert.Contains(t, output, "| **Истории** | `STORY-1` |")
+}
@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// formatTraits форматирует особенности выполнения теста в виде значков
+func (g *Generator) formatTraits(traits types.ExecutionTraits) string {
+	var badges []string
+
+	if traits.Parallel {
+		badges = append(badges, "⚡ Параллельный")
+	}
+	if traits.Cleanup {
+		badges = append(badges, "🧹 t.Cleanup")
+	}
+	if traits.Defer {
+		badges = append(badges, "↩️ defer")
+	}
+	if len(traits.Helpers) > 0 {
+		badges = append(badges, "🛠️ Хелперы "+formatCodeList(traits.Helpers))
+	}
+	if len(traits.Timeouts) > 0 {
+		badges = append(badges, "⏱️ Таймаут "+formatCodeList(traits.Timeouts))
+	}
+	if len(traits.Sleeps) > 0 {
+		badges = append(badges, "💤 Sleep "+formatCodeList(traits.Sleeps))
+	}
+
+	return strings.Join(badges, " · ")
+}
+
+// generateTraitsStatistics генерирует статистику особенностей выполнения
+func (g *Generator) generateTraitsStatistics(sb io.StringWriter, stats *types.Statistics) {
+	sb.WriteString(fmt.Sprintf("- **Параллельных тестов:** %d (%.1f%%)\n", stats.ParallelTests, stats.ParallelPercent()))
+	if stats.TimeoutTests > 0 {
+		sb.WriteString(fmt.Sprintf("- **С таймаутами:** %d\n", stats.TimeoutTests))
+	}
+	if stats.SleepTests > 0 {
+		sb.WriteString(fmt.Sprintf("- **С time.Sleep:** %d\n", stats.SleepTests))
+	}
+}
+
+// formatCodeList форматирует значения как код через запятую
+func formatCodeList(values []string) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = "`" + value + "`"
+	}
+	return strings.Join(formatted, ", ")
+}
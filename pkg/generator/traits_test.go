@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func TestGenerator_formatTraits(t *testing.T) {
+	gen := New(nil)
+
+	traits := types.ExecutionTraits{
+		Parallel: true,
+		Defer:    true,
+		Helpers:  []string{"setupDB", "newClient"},
+		Sleeps:   []string{"time.Second"},
+	}
+
+	assert.Equal(t, "⚡ Параллельный · ↩️ defer · 🛠️ Хелперы `setupDB`, `newClient` · 💤 Sleep `time.Second`",
+		gen.formatTraits(traits))
+	assert.Empty(t, gen.formatTraits(types.ExecutionTraits{}))
+}
+
+func TestGenerator_generateTraitsStatistics(t *testing.T) {
+	gen := New(nil)
+
+	var sb strings.Builder
+	gen.generateTraitsStatistics(&sb, &types.Statistics{TotalTests: 8, ParallelTests: 3, SleepTests: 1})
+
+	output := sb.String()
+	assert.Contains(t, output, "- **Параллельных тестов:** 3 (37.5%)\n")
+	assert.Contains(t, output, "- **С time.Sleep:** 1\n")
+	assert.NotContains(t, output, "С таймаутами")
+}
+
+func TestGenerator_generateTestSection_Traits(t *testing.T) {
+	gen := New(nil)
+
+	testInfo := types.TestInfo{
+		Name:    "TestParallel",
+		Type:    types.UnitTest,
+		Package: "example",
+		File:    "example_test.go",
+		Line:    5,
+		Traits:  types.ExecutionTraits{Parallel: true, Cleanup: true},
+	}
+
+	var sb strings.Builder
+	gen.generateTestSection(&sb, testInfo)
+
+	assert.Contains(t, sb.String(), "| **Выполнение** | ⚡ Параллельный · 🧹 t.Cleanup |")
+}
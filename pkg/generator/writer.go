@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"bufio"
+	"io"
+)
+
+// docWriter буферизует запись документа и запоминает первую ошибку.
+// После ошибки последующие записи игнорируются, поэтому функциям генерации
+// не нужно проверять результат каждого WriteString.
+type docWriter struct {
+	buf *bufio.Writer
+	err error
+}
+
+// newDocWriter создает буферизованный writer поверх w
+func newDocWriter(w io.Writer) *docWriter {
+	return &docWriter{buf: bufio.NewWriter(w)}
+}
+
+// WriteString записывает строку, если ранее не было ошибок
+func (d *docWriter) WriteString(s string) (int, error) {
+	if d.err != nil {
+		return 0, d.err
+	}
+	n, err := d.buf.WriteString(s)
+	d.err = err
+	return n, err
+}
+
+// Flush сбрасывает буфер и возвращает первую ошибку записи
+func (d *docWriter) Flush() error {
+	if d.err != nil {
+		return d.err
+	}
+	d.err = d.buf.Flush()
+	return d.err
+}
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// limitedWriter принимает не больше limit байт, затем возвращает ошибку
+type limitedWriter struct {
+	limit  int
+	writes int
+	sb     strings.Builder
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.sb.Len()+len(p) > w.limit {
+		return 0, errors.New("limit exceeded")
+	}
+	return w.sb.Write(p)
+}
+
+func TestDocWriter(t *testing.T) {
+	w := &limitedWriter{limit: 5}
+	dw := newDocWriter(w)
+
+	_, err := dw.WriteString("hello")
+	require.NoError(t, err)
+	require.NoError(t, dw.Flush())
+	assert.Equal(t, "hello", w.sb.String())
+
+	_, _ = dw.WriteString(" world")
+	assert.EqualError(t, dw.Flush(), "limit exceeded")
+
+	// После ошибки записи игнорируются
+	writes := w.writes
+	_, err = dw.WriteString("again")
+	assert.EqualError(t, err, "limit exceeded")
+	assert.EqualError(t, dw.Flush(), "limit exceeded")
+	assert.Equal(t, writes, w.writes)
+}
+
+func TestGenerator_WriteMarkdown(t *testing.T) {
+	gen := New(nil)
+
+	var tests []types.TestInfo
+	for i := 0; i < 500; i++ {
+		tests = append(tests, types.TestInfo{
+			Name:        "TestLarge",
+			Type:        types.UnitTest,
+			Package:     "large",
+			File:        "large_test.go",
+			Line:        i,
+			Description: strings.Repeat("описание ", 20),
+		})
+	}
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{"large": {Name: "large", Tests: tests}},
+	}
+	result.CalculateStats()
+
+	var sb strings.Builder
+	require.NoError(t, gen.WriteMarkdown(&sb, result))
+	assert.Contains(t, sb.String(), "- **Всего тестов:** 500")
+
+	w := &limitedWriter{limit: 10000}
+	assert.EqualError(t, gen.WriteMarkdown(w, result), "limit exceeded")
+	assert.LessOrEqual(t, w.sb.Len(), 10000)
+}
@@ -0,0 +1,397 @@
+// Package gitfs предоставляет дерево git ревизии как файловую систему fs.FS.
+// Содержимое читается из локального репозитория командами git ls-tree и
+// git cat-file --batch, поэтому рабочая копия не меняется и не требует
+// переключения.
+package gitfs
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FS - файловая система дерева одной ревизии репозитория. Содержимое файлов
+// читает один процесс git cat-file --batch, который запускается при первом
+// чтении и завершается методом Close.
+type FS struct {
+	repo    string
+	rev     string
+	dir     string
+	entries map[string]*entry
+
+	mu    sync.Mutex
+	batch *catFile
+}
+
+// entry описывает файл или директорию дерева
+type entry struct {
+	name     string
+	mode     fs.FileMode
+	size     int64
+	object   string
+	children []*entry
+}
+
+// Open читает дерево ревизии rev репозитория, содержащего директорию dir.
+// Ревизия - любое выражение, понятное git: ветка, тег, коммит, HEAD~1.
+func Open(dir, rev string) (*FS, error) {
+	if dir == "" {
+		dir = "."
+	}
+
+	top, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := git(dir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, err
+	}
+	commit, err := git(dir, "rev-parse", "--verify", "--quiet", rev+"^{tree}")
+	if err != nil {
+		return nil, fmt.Errorf("ревизия %q не найдена", rev)
+	}
+
+	fsys := &FS{
+		repo:    strings.TrimSpace(string(top)),
+		rev:     rev,
+		dir:     path.Clean("./" + strings.TrimSpace(string(prefix))),
+		entries: map[string]*entry{".": {name: ".", mode: fs.ModeDir | 0555}},
+	}
+
+	listing, err := git(fsys.repo, "ls-tree", "-r", "-t", "-z", "--long", strings.TrimSpace(string(commit)))
+	if err != nil {
+		return nil, err
+	}
+	if err := fsys.load(listing); err != nil {
+		return nil, err
+	}
+	return fsys, nil
+}
+
+// Revision возвращает ревизию, дерево которой представляет файловая система
+func (fsys *FS) Revision() string {
+	return fsys.rev
+}
+
+// Dir возвращает путь директории, переданной в Open, относительно корня
+// репозитория в формате fs.FS ("." для корня)
+func (fsys *FS) Dir() string {
+	return fsys.dir
+}
+
+// load разбирает вывод git ls-tree -r -t -z --long:
+// "<режим> <тип> <объект> <размер>\t<путь>\x00"
+func (fsys *FS) load(listing []byte) error {
+	for _, record := range bytes.Split(listing, []byte{0}) {
+		if len(record) == 0 {
+			continue
+		}
+
+		tab := bytes.IndexByte(record, '\t')
+		if tab < 0 {
+			return fmt.Errorf("неожиданный вывод git ls-tree: %q", record)
+		}
+		fields := strings.Fields(string(record[:tab]))
+		if len(fields) != 4 {
+			return fmt.Errorf("неожиданный вывод git ls-tree: %q", record)
+		}
+		name := string(record[tab+1:])
+
+		e := &entry{name: path.Base(name), object: fields[2]}
+		switch fields[1] {
+		case "tree":
+			e.mode = fs.ModeDir | 0555
+		case "blob":
+			e.mode = 0444
+			if fields[0] == "100755" {
+				e.mode = 0555
+			}
+			if fields[0] == "120000" {
+				e.mode = fs.ModeSymlink | 0777
+			}
+			e.size, _ = strconv.ParseInt(fields[3], 10, 64)
+		default:
+			// Подмодули (commit) не входят в дерево ревизии
+			continue
+		}
+
+		fsys.entries[name] = e
+		parent := fsys.entries[path.Dir(name)]
+		if parent == nil {
+			return fmt.Errorf("в выводе git ls-tree нет директории %s", path.Dir(name))
+		}
+		parent.children = append(parent.children, e)
+	}
+
+	for _, e := range fsys.entries {
+		sort.Slice(e.children, func(i, j int) bool { return e.children[i].name < e.children[j].name })
+	}
+	return nil
+}
+
+// Open открывает файл или директорию дерева
+func (fsys *FS) Open(name string) (fs.File, error) {
+	e, err := fsys.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.mode.IsDir() {
+		return &dir{entry: e}, nil
+	}
+
+	data, err := fsys.read(e)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &file{entry: e, Reader: bytes.NewReader(data)}, nil
+}
+
+// ReadFile читает содержимое файла дерева
+func (fsys *FS) ReadFile(name string) ([]byte, error) {
+	e, err := fsys.lookup("read", name)
+	if err != nil {
+		return nil, err
+	}
+	if e.mode.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fmt.Errorf("это директория")}
+	}
+
+	data, err := fsys.read(e)
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	return data, nil
+}
+
+// ReadDir возвращает содержимое директории дерева, упорядоченное по имени
+func (fsys *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	e, err := fsys.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !e.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fmt.Errorf("это не директория")}
+	}
+	return dirEntries(e.children), nil
+}
+
+// Stat возвращает сведения о файле или директории дерева
+func (fsys *FS) Stat(name string) (fs.FileInfo, error) {
+	e, err := fsys.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// lookup находит запись дерева по имени в формате fs.FS
+func (fsys *FS) lookup(op, name string) (*entry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	e, ok := fsys.entries[name]
+	if !ok {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return e, nil
+}
+
+// read читает содержимое объекта файла из процесса git cat-file --batch
+func (fsys *FS) read(e *entry) ([]byte, error) {
+	fsys.mu.Lock()
+	defer fsys.mu.Unlock()
+
+	if fsys.batch == nil {
+		batch, err := startCatFile(fsys.repo)
+		if err != nil {
+			return nil, err
+		}
+		fsys.batch = batch
+	}
+
+	data, err := fsys.batch.read(e.object)
+	if err != nil {
+		// После ошибки поток процесса может быть рассинхронизирован:
+		// следующее чтение запустит новый процесс
+		fsys.batch.close()
+		fsys.batch = nil
+		return nil, err
+	}
+	return data, nil
+}
+
+// Close завершает процесс git cat-file. После Close файловую систему можно
+// использовать дальше: процесс будет запущен заново при следующем чтении.
+func (fsys *FS) Close() error {
+	fsys.mu.Lock()
+	defer fsys.mu.Unlock()
+
+	if fsys.batch == nil {
+		return nil
+	}
+	err := fsys.batch.close()
+	fsys.batch = nil
+	return err
+}
+
+// catFile - запущенный процесс git cat-file --batch
+type catFile struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	stderr bytes.Buffer
+}
+
+// startCatFile запускает git cat-file --batch в репозитории repo
+func startCatFile(repo string) (*catFile, error) {
+	c := &catFile{cmd: exec.Command("git", "-C", repo, "cat-file", "--batch")}
+	c.cmd.Stderr = &c.stderr
+
+	stdin, err := c.cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := c.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.cmd.Start(); err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+
+	c.stdin = stdin
+	c.stdout = bufio.NewReader(stdout)
+	return c, nil
+}
+
+// read запрашивает объект и читает ответ "<объект> <тип> <размер>\n<данные>\n"
+func (c *catFile) read(object string) ([]byte, error) {
+	if _, err := io.WriteString(c.stdin, object+"\n"); err != nil {
+		return nil, c.failure(err)
+	}
+
+	header, err := c.stdout.ReadString('\n')
+	if err != nil {
+		return nil, c.failure(err)
+	}
+	fields := strings.Fields(header)
+	if len(fields) == 2 && fields[1] == "missing" {
+		return nil, fmt.Errorf("git cat-file: объект %s не найден", object)
+	}
+	if len(fields) != 3 || fields[1] != "blob" {
+		return nil, fmt.Errorf("неожиданный вывод git cat-file: %q", header)
+	}
+	size, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("неожиданный вывод git cat-file: %q", header)
+	}
+
+	// Содержимое и завершающий перевод строки
+	data := make([]byte, size+1)
+	if _, err := io.ReadFull(c.stdout, data); err != nil {
+		return nil, c.failure(err)
+	}
+	return data[:size], nil
+}
+
+// failure дополняет ошибку обмена с процессом сообщением git
+func (c *catFile) failure(err error) error {
+	if message := strings.TrimSpace(c.stderr.String()); message != "" {
+		return fmt.Errorf("git cat-file: %s", message)
+	}
+	return fmt.Errorf("git cat-file: %w", err)
+}
+
+// close закрывает ввод процесса и дожидается его завершения
+func (c *catFile) close() error {
+	c.stdin.Close()
+	if err := c.cmd.Wait(); err != nil {
+		return c.failure(err)
+	}
+	return nil
+}
+
+// git выполняет команду git в директории dir и возвращает ее вывод
+func git(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], message)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return output, nil
+}
+
+// entry реализует fs.FileInfo и fs.DirEntry
+
+func (e *entry) Name() string               { return e.name }
+func (e *entry) Size() int64                { return e.size }
+func (e *entry) Mode() fs.FileMode          { return e.mode }
+func (e *entry) ModTime() time.Time         { return time.Time{} }
+func (e *entry) IsDir() bool                { return e.mode.IsDir() }
+func (e *entry) Sys() interface{}           { return nil }
+func (e *entry) Type() fs.FileMode          { return e.mode.Type() }
+func (e *entry) Info() (fs.FileInfo, error) { return e, nil }
+
+// dirEntries приводит записи дерева к fs.DirEntry
+func dirEntries(entries []*entry) []fs.DirEntry {
+	result := make([]fs.DirEntry, len(entries))
+	for i, e := range entries {
+		result[i] = e
+	}
+	return result
+}
+
+// file - открытый файл дерева
+type file struct {
+	entry *entry
+	*bytes.Reader
+}
+
+func (f *file) Stat() (fs.FileInfo, error) { return f.entry, nil }
+func (f *file) Close() error               { return nil }
+
+// dir - открытая директория дерева
+type dir struct {
+	entry  *entry
+	offset int
+}
+
+func (d *dir) Stat() (fs.FileInfo, error) { return d.entry, nil }
+func (d *dir) Close() error               { return nil }
+
+func (d *dir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.entry.name, Err: fmt.Errorf("это директория")}
+}
+
+// ReadDir возвращает следующие n записей директории по правилам fs.ReadDirFile
+func (d *dir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entry.children[d.offset:]
+	if n <= 0 {
+		d.offset += len(rest)
+		return dirEntries(rest), nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.offset += n
+	return dirEntries(rest[:n]), nil
+}
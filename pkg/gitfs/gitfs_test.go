@@ -0,0 +1,143 @@
+package gitfs
+
+import (
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRepo создает репозиторий с двумя коммитами и возвращает путь к нему
+func createRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git не установлен")
+	}
+
+	repo := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	write := func(name, content string) {
+		filename := filepath.Join(repo, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0755))
+		require.NoError(t, os.WriteFile(filename, []byte(content), 0644))
+	}
+
+	run("init", "-q")
+	write("README.md", "# Проект\n")
+	write("pkg/auth/auth_test.go", "package auth\n")
+	write("pkg/auth/testdata/users.json", "[]\n")
+	run("add", "-A")
+	run("commit", "-q", "-m", "initial")
+	run("tag", "v1.0.0")
+
+	write("pkg/auth/auth_test.go", "package auth\n\n// изменено\n")
+	write("pkg/api/api_test.go", "package api\n")
+	run("add", "-A")
+	run("commit", "-q", "-m", "second")
+
+	return repo
+}
+
+func TestOpen(t *testing.T) {
+	repo := createRepo(t)
+
+	fsys, err := Open(repo, "v1.0.0")
+	require.NoError(t, err)
+	defer fsys.Close()
+	assert.Equal(t, "v1.0.0", fsys.Revision())
+	assert.Equal(t, ".", fsys.Dir())
+
+	require.NoError(t, fstest.TestFS(fsys, "README.md", "pkg/auth/auth_test.go", "pkg/auth/testdata/users.json"))
+
+	data, err := fs.ReadFile(fsys, "pkg/auth/auth_test.go")
+	require.NoError(t, err)
+	assert.Equal(t, "package auth\n", string(data))
+
+	_, err = fs.Stat(fsys, "pkg/api")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	entries, err := fs.ReadDir(fsys, "pkg")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "auth", entries[0].Name())
+	assert.True(t, entries[0].IsDir())
+}
+
+func TestOpen_Head(t *testing.T) {
+	repo := createRepo(t)
+
+	// Изменения рабочей копии не видны в дереве ревизии
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "pkg", "auth", "auth_test.go"), []byte("package changed\n"), 0644))
+
+	fsys, err := Open(filepath.Join(repo, "pkg", "auth"), "HEAD")
+	require.NoError(t, err)
+	defer fsys.Close()
+	assert.Equal(t, "pkg/auth", fsys.Dir())
+
+	data, err := fsys.ReadFile("pkg/auth/auth_test.go")
+	require.NoError(t, err)
+	assert.Equal(t, "package auth\n\n// изменено\n", string(data))
+
+	_, err = fsys.Stat("pkg/api/api_test.go")
+	assert.NoError(t, err)
+}
+
+func TestFS_ReadBatch(t *testing.T) {
+	repo := createRepo(t)
+
+	fsys, err := Open(repo, "HEAD")
+	require.NoError(t, err)
+	defer fsys.Close()
+
+	// Все файлы читаются одним процессом git cat-file --batch
+	_, err = fsys.ReadFile("README.md")
+	require.NoError(t, err)
+	batch := fsys.batch
+	require.NotNil(t, batch)
+
+	for _, name := range []string{"pkg/api/api_test.go", "pkg/auth/testdata/users.json", "README.md"} {
+		_, err := fsys.ReadFile(name)
+		require.NoError(t, err, name)
+	}
+	assert.Same(t, batch, fsys.batch)
+
+	data, err := fsys.ReadFile("pkg/auth/testdata/users.json")
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", string(data))
+
+	// После Close следующее чтение запускает новый процесс
+	require.NoError(t, fsys.Close())
+	assert.Nil(t, fsys.batch)
+	data, err = fsys.ReadFile("README.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Проект\n", string(data))
+}
+
+func TestOpen_Errors(t *testing.T) {
+	repo := createRepo(t)
+
+	_, err := Open(repo, "no-such-branch")
+	assert.ErrorContains(t, err, "no-such-branch")
+
+	_, err = Open(t.TempDir(), "HEAD")
+	assert.Error(t, err)
+
+	fsys, err := Open(repo, "HEAD")
+	require.NoError(t, err)
+	_, err = fsys.Open("../README.md")
+	assert.ErrorIs(t, err, fs.ErrInvalid)
+	_, err = fsys.ReadFile("pkg")
+	assert.Error(t, err)
+}
@@ -0,0 +1,215 @@
+package parser
+
+import (
+	"strings"
+	"time"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// annotationHandler обрабатывает встроенную аннотацию
+type annotationHandler func(a Annotation, testInfo *types.TestInfo)
+
+// builtinAnnotations сопоставляет имена встроенных аннотаций обработчикам
+var builtinAnnotations = map[string]annotationHandler{
+	// @type: unit|integration|functional|e2e|performance|security|regression|smoke
+	"type": func(a Annotation, testInfo *types.TestInfo) {
+		testInfo.Type = types.TestType(a.Value)
+		testInfo.ExplicitType = true
+	},
+
+	// @author: имя автора
+	"author": func(a Annotation, testInfo *types.TestInfo) {
+		testInfo.Author = a.Value
+	},
+
+	// @owner: @team-a, @team-b (переопределяет CODEOWNERS)
+	"owner": func(a Annotation, testInfo *types.TestInfo) {
+		testInfo.Owners = append(testInfo.Owners, splitList(a.Value)...)
+	},
+
+	// @requirement: REQ-1, REQ-2
+	"requirement": func(a Annotation, testInfo *types.TestInfo) {
+		testInfo.Requirements = appendUnique(testInfo.Requirements, splitList(a.Value)...)
+	},
+
+	// @issue: BUG-42
+	"issue": func(a Annotation, testInfo *types.TestInfo) {
+		testInfo.Issues = appendUnique(testInfo.Issues, splitList(a.Value)...)
+	},
+
+	// @story: STORY-7
+	"story": func(a Annotation, testInfo *types.TestInfo) {
+		testInfo.Stories = appendUnique(testInfo.Stories, splitList(a.Value)...)
+	},
+
+	// @requires: env:DATABASE_URL, docker, redis
+	"requires": func(a Annotation, testInfo *types.TestInfo) {
+		for _, item := range strings.Split(a.Value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				testInfo.AddDependency(types.ParseDependency(item))
+			}
+		}
+	},
+
+	// @tags: tag1,tag2,tag3
+	"tags": func(a Annotation, testInfo *types.TestInfo) {
+		for _, tag := range strings.Split(a.Value, ",") {
+			testInfo.Tags = append(testInfo.Tags, strings.TrimSpace(tag))
+		}
+	},
+
+	// @testcase: название кейса - описание
+	"testcase": func(a Annotation, testInfo *types.TestInfo) {
+		name, description := splitNameDescription(a.Value)
+		testInfo.TestCases = append(testInfo.TestCases, types.TestCase{
+			Name:        name,
+			Description: description,
+		})
+	},
+
+	// @step: действие - ожидаемый результат
+	// Строки продолжения с отступом становятся описанием шага.
+	"step": func(a Annotation, testInfo *types.TestInfo) {
+		stepLines := strings.SplitN(a.Raw, "\n", 2)
+		action, expected := splitNameDescription(strings.TrimSpace(stepLines[0]))
+
+		step := types.Step{
+			Action:   action,
+			Expected: expected,
+		}
+
+		if len(stepLines) > 1 {
+			step.Description = foldLines(stepLines[1])
+		}
+
+		// Добавляем к последнему тест-кейсу
+		if len(testInfo.TestCases) > 0 {
+			lastIndex := len(testInfo.TestCases) - 1
+			testInfo.TestCases[lastIndex].Steps = append(testInfo.TestCases[lastIndex].Steps, step)
+		}
+	},
+
+	// @input: входные данные последнего тест-кейса (допускается многострочный JSON/YAML)
+	"input": func(a Annotation, testInfo *types.TestInfo) {
+		lastTestCase(testInfo).Input = annotationPayload(a.Raw)
+	},
+
+	// @expected: ожидаемый результат последнего тест-кейса
+	"expected": func(a Annotation, testInfo *types.TestInfo) {
+		lastTestCase(testInfo).Expected = annotationPayload(a.Raw)
+	},
+
+	// @scenario: название сценария - описание
+	"scenario": func(a Annotation, testInfo *types.TestInfo) {
+		name, description := splitNameDescription(a.Value)
+		testInfo.TestCases = append(testInfo.TestCases, types.TestCase{
+			Name:        name,
+			Description: description,
+			Scenario:    []types.ScenarioStep{},
+		})
+	},
+
+	// @given, @when, @then, @and, @but: шаги сценария
+	"given": gherkinStep(types.Given),
+	"when":  gherkinStep(types.When),
+	"then":  gherkinStep(types.Then),
+	"and":   gherkinStep(types.And),
+	"but":   gherkinStep(types.But),
+
+	// @priority: P0..P3 или critical, high, medium, low. Нераспознанное
+	// значение сохраняется в метаданные.
+	"priority": func(a Annotation, testInfo *types.TestInfo) {
+		if priority, ok := types.ParsePriority(a.Value); ok {
+			testInfo.Priority = priority
+			return
+		}
+		extractMetadata(a, testInfo)
+	},
+
+	// @severity: blocker, critical, major, minor или trivial. Нераспознанное
+	// значение сохраняется в метаданные.
+	"severity": func(a Annotation, testInfo *types.TestInfo) {
+		if severity, ok := types.ParseSeverity(a.Value); ok {
+			testInfo.Severity = severity
+			return
+		}
+		extractMetadata(a, testInfo)
+	},
+
+	// @created: дата создания
+	"created": func(a Annotation, testInfo *types.TestInfo) {
+		if date, err := time.Parse("2006-01-02", a.Value); err == nil {
+			testInfo.Created = date
+		}
+	},
+
+	// @updated: дата обновления
+	"updated": func(a Annotation, testInfo *types.TestInfo) {
+		if date, err := time.Parse("2006-01-02", a.Value); err == nil {
+			testInfo.Updated = date
+		}
+	},
+}
+
+// extractBuiltinAnnotation обрабатывает встроенные аннотации
+func extractBuiltinAnnotation(a Annotation, testInfo *types.TestInfo) bool {
+	handler, ok := builtinAnnotations[a.Name]
+	if !ok {
+		return false
+	}
+	handler(a, testInfo)
+	return true
+}
+
+// extractMetadata сохраняет нераспознанную аннотацию @key: value в метаданные
+func extractMetadata(a Annotation, testInfo *types.TestInfo) bool {
+	if testInfo.Metadata == nil {
+		testInfo.Metadata = make(map[string]string)
+	}
+	testInfo.Metadata[a.Name] = a.Value
+	return true
+}
+
+// gherkinStep возвращает обработчик шага сценария с заданным ключевым словом
+func gherkinStep(keyword types.GherkinKeyword) annotationHandler {
+	return func(a Annotation, testInfo *types.TestInfo) {
+		testCase := lastTestCase(testInfo)
+		testCase.Scenario = append(testCase.Scenario, types.ScenarioStep{
+			Keyword: keyword,
+			Text:    a.Value,
+		})
+	}
+}
+
+// splitNameDescription разбивает значение вида "название - описание"
+func splitNameDescription(value string) (string, string) {
+	name, description, _ := strings.Cut(value, "-")
+	return strings.TrimSpace(name), strings.TrimSpace(description)
+}
+
+// lastTestCase возвращает последний тест-кейс теста. Если кейсов еще нет,
+// создается кейс с именем теста.
+func lastTestCase(testInfo *types.TestInfo) *types.TestCase {
+	if len(testInfo.TestCases) == 0 {
+		testInfo.TestCases = append(testInfo.TestCases, types.TestCase{Name: testInfo.Name})
+	}
+	return &testInfo.TestCases[len(testInfo.TestCases)-1]
+}
+
+// annotationPayload извлекает значение аннотации с сохранением переводов строк.
+// Обрамляющий блок кода ``` удаляется.
+func annotationPayload(raw string) string {
+	payload := strings.TrimSpace(raw)
+
+	lines := strings.Split(payload, "\n")
+	if len(lines) > 1 && isFence(lines[0]) {
+		lines = lines[1:]
+		if isFence(lines[len(lines)-1]) {
+			lines = lines[:len(lines)-1]
+		}
+		payload = strings.Join(dedent(lines), "\n")
+	}
+
+	return strings.TrimSpace(payload)
+}
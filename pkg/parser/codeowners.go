@@ -0,0 +1,268 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// codeOwnersLocations перечисляет стандартные расположения файла CODEOWNERS
+// относительно корня репозитория (GitHub и GitLab)
+var codeOwnersLocations = []string{
+	"CODEOWNERS",
+	filepath.Join(".github", "CODEOWNERS"),
+	filepath.Join(".gitlab", "CODEOWNERS"),
+	filepath.Join("docs", "CODEOWNERS"),
+}
+
+// CodeOwners содержит правила владения файлами из CODEOWNERS
+type CodeOwners struct {
+	sections []ownerSection
+}
+
+// ownerSection представляет секцию GitLab. У файлов GitHub секция одна.
+type ownerSection struct {
+	name          string
+	defaultOwners []string
+	rules         []ownerRule
+}
+
+// ownerRule представляет одну строку правила: шаблон пути и владельцы
+type ownerRule struct {
+	pattern string
+	owners  []string
+}
+
+// ParseCodeOwners разбирает содержимое файла CODEOWNERS в синтаксисе GitHub или GitLab
+func ParseCodeOwners(r io.Reader) (*CodeOwners, error) {
+	co := &CodeOwners{
+		sections: []ownerSection{{}},
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Заголовок секции GitLab: [Name], ^[Name], [Name][2] @default-owner
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			co.sections = append(co.sections, parseOwnerSection(line))
+			continue
+		}
+
+		fields := strings.Fields(stripInlineComment(line))
+		if len(fields) == 0 {
+			continue
+		}
+
+		section := &co.sections[len(co.sections)-1]
+		rule := ownerRule{
+			pattern: strings.ReplaceAll(fields[0], `\#`, "#"),
+			owners:  fields[1:],
+		}
+		if len(rule.owners) == 0 {
+			rule.owners = section.defaultOwners
+		}
+		section.rules = append(section.rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return co, nil
+}
+
+// LoadCodeOwners загружает файл CODEOWNERS с диска
+func LoadCodeOwners(filename string) (*CodeOwners, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseCodeOwners(f)
+}
+
+// loadCodeOwnersFS загружает CODEOWNERS из стандартных расположений в корне fsys.
+// Файл, явно указанный в конфигурации, читается из fsys, если путь относительный,
+// и с диска, если абсолютный.
+func (p *Parser) loadCodeOwnersFS(fsys fs.FS, config *types.Config) (*CodeOwners, error) {
+	if config.CodeOwnersFile != "" {
+		if filepath.IsAbs(config.CodeOwnersFile) {
+			return LoadCodeOwners(config.CodeOwnersFile)
+		}
+		f, err := fsys.Open(path.Clean(filepath.ToSlash(config.CodeOwnersFile)))
+		if err != nil {
+			return nil, err
+		}
+		co, err := ParseCodeOwners(f)
+		f.Close()
+		return co, err
+	}
+
+	for _, location := range codeOwnersLocations {
+		f, err := fsys.Open(filepath.ToSlash(location))
+		if err != nil {
+			continue
+		}
+
+		if info, err := f.Stat(); err != nil || info.IsDir() {
+			f.Close()
+			continue
+		}
+		co, err := ParseCodeOwners(f)
+		f.Close()
+		return co, err
+	}
+
+	return nil, nil
+}
+
+// FindCodeOwners ищет файл CODEOWNERS, поднимаясь от start к корню файловой системы.
+// Возвращает путь к файлу и корень репозитория, относительно которого заданы шаблоны.
+// Поиск останавливается на директории, содержащей .git.
+func FindCodeOwners(start string) (filename, root string) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", ""
+	}
+
+	for {
+		for _, location := range codeOwnersLocations {
+			candidate := filepath.Join(dir, location)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate, dir
+			}
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", ""
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		dir = parent
+	}
+}
+
+// CodeOwnersRoot возвращает корень репозитория для явно указанного файла CODEOWNERS
+func CodeOwnersRoot(filename string) string {
+	dir := filepath.Dir(filename)
+	switch filepath.Base(dir) {
+	case ".github", ".gitlab", "docs":
+		return filepath.Dir(dir)
+	default:
+		return dir
+	}
+}
+
+// Owners возвращает владельцев файла по пути относительно корня репозитория.
+// Внутри секции побеждает последнее совпавшее правило; владельцы разных
+// секций GitLab объединяются.
+func (co *CodeOwners) Owners(relPath string) []string {
+	relPath = strings.TrimPrefix(filepath.ToSlash(relPath), "./")
+
+	var owners []string
+	seen := make(map[string]bool)
+
+	for _, section := range co.sections {
+		var matched *ownerRule
+		for i := range section.rules {
+			if matchOwnerPattern(section.rules[i].pattern, relPath) {
+				matched = &section.rules[i]
+			}
+		}
+		if matched == nil {
+			continue
+		}
+
+		for _, owner := range matched.owners {
+			if !seen[owner] {
+				seen[owner] = true
+				owners = append(owners, owner)
+			}
+		}
+	}
+
+	return owners
+}
+
+// parseOwnerSection разбирает заголовок секции GitLab
+func parseOwnerSection(line string) ownerSection {
+	line = strings.TrimPrefix(stripInlineComment(line), "^")
+
+	section := ownerSection{}
+	end := strings.Index(line, "]")
+	if end < 0 {
+		return section
+	}
+	section.name = strings.TrimSpace(line[1:end])
+
+	rest := line[end+1:]
+	// Необязательное количество требуемых approvals: [Name][2]
+	if strings.HasPrefix(rest, "[") {
+		if closing := strings.Index(rest, "]"); closing >= 0 {
+			rest = rest[closing+1:]
+		}
+	}
+	section.defaultOwners = strings.Fields(rest)
+
+	return section
+}
+
+// stripInlineComment удаляет комментарий в конце строки правила
+func stripInlineComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] != '\\') {
+			return strings.TrimSpace(line[:i])
+		}
+	}
+	return line
+}
+
+// matchOwnerPattern сопоставляет путь с шаблоном CODEOWNERS по правилам gitignore:
+// шаблон без слэша совпадает на любом уровне, ведущий или внутренний слэш
+// привязывает шаблон к корню, а совпадение с директорией распространяется
+// на всё её содержимое.
+func matchOwnerPattern(pattern, relPath string) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" {
+		return false
+	}
+
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+	if !anchored {
+		trimmed = "**/" + trimmed
+	}
+
+	if !dirOnly && matchGlob(trimmed, relPath) {
+		return true
+	}
+
+	// Шаблон вида docs/* относится только к непосредственному содержимому
+	lastSegment := trimmed[strings.LastIndex(trimmed, "/")+1:]
+	if !dirOnly && strings.ContainsAny(lastSegment, "*?[") && lastSegment != "**" {
+		return false
+	}
+
+	segments := strings.Split(relPath, "/")
+	for i := 1; i < len(segments); i++ {
+		if matchGlob(trimmed, strings.Join(segments[:i], "/")) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func TestParseCodeOwners_GitHub(t *testing.T) {
+	content := `# Владельцы по умолчанию
+*                 @org/core
+*.md              @org/docs
+/pkg/payments/    @org/payments @alice
+docs/*            @org/docs-team
+apps/             @org/apps  # комментарий в конце строки
+`
+
+	co, err := ParseCodeOwners(strings.NewReader(content))
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{"main_test.go", []string{"@org/core"}},
+		{"README.md", []string{"@org/docs"}},
+		{"pkg/payments/payment_test.go", []string{"@org/payments", "@alice"}},
+		{"pkg/payments/sub/refund_test.go", []string{"@org/payments", "@alice"}},
+		{"other/pkg/payments/x_test.go", []string{"@org/core"}},
+		{"docs/guide_test.go", []string{"@org/docs-team"}},
+		{"docs/nested/guide_test.go", []string{"@org/core"}},
+		{"services/apps/api_test.go", []string{"@org/apps"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, co.Owners(tt.path))
+		})
+	}
+}
+
+func TestParseCodeOwners_GitLabSections(t *testing.T) {
+	content := `[Backend] @org/backend
+/pkg/
+/pkg/api/ @org/api
+
+^[Security][2] @org/security
+**/auth/
+`
+
+	co, err := ParseCodeOwners(strings.NewReader(content))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"@org/backend"}, co.Owners("pkg/types/types_test.go"))
+	assert.Equal(t, []string{"@org/api"}, co.Owners("pkg/api/handler_test.go"))
+	assert.Equal(t, []string{"@org/backend", "@org/security"}, co.Owners("pkg/auth/login_test.go"))
+	assert.Empty(t, co.Owners("cmd/main_test.go"))
+}
+
+func TestFindCodeOwners(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "codeowners_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, ".git"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, ".github"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "pkg", "sub"), 0755))
+
+	codeowners := filepath.Join(tmpDir, ".github", "CODEOWNERS")
+	require.NoError(t, os.WriteFile(codeowners, []byte("* @org/core\n"), 0644))
+
+	filename, root := FindCodeOwners(filepath.Join(tmpDir, "pkg", "sub"))
+	assert.Equal(t, codeowners, filename)
+	assert.Equal(t, tmpDir, root)
+	assert.Equal(t, tmpDir, CodeOwnersRoot(codeowners))
+}
+
+func TestParser_loadCodeOwnersFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		".github/CODEOWNERS": {Data: []byte("* @org/core\n")},
+		"owners/TEAMS":       {Data: []byte("* @org/payments\n")},
+		"docs/CODEOWNERS":    {Data: []byte("* @org/docs\n")},
+	}
+
+	owners, err := New().loadCodeOwnersFS(fsys, types.DefaultConfig())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@org/core"}, owners.Owners("api/api_test.go"))
+
+	// Относительный путь из конфигурации читается из fsys, а не с диска
+	config := types.DefaultConfig()
+	config.CodeOwnersFile = "./owners/TEAMS"
+	owners, err = New().loadCodeOwnersFS(fsys, config)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@org/payments"}, owners.Owners("api/api_test.go"))
+
+	config.CodeOwnersFile = "owners/MISSING"
+	_, err = New().loadCodeOwnersFS(fsys, config)
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// commentLines разворачивает группу комментариев в строки текста без маркеров
+// комментариев. Для строчных комментариев удаляется только один пробел после //,
+// поэтому отступ строки продолжения сохраняется. Блочные комментарии
+// разбиваются на строки, декоративные "*" в начале строк удаляются.
+func commentLines(docGroup *ast.CommentGroup) []string {
+	var lines []string
+
+	for _, comment := range docGroup.List {
+		if strings.HasPrefix(comment.Text, "//") {
+			line := strings.TrimPrefix(comment.Text, "//")
+			line = strings.TrimPrefix(line, " ")
+			lines = append(lines, strings.TrimRight(line, " \t\r"))
+			continue
+		}
+
+		lines = append(lines, blockCommentLines(comment.Text)...)
+	}
+
+	return lines
+}
+
+// blockCommentLines разбивает блочный комментарий /* */ на строки
+func blockCommentLines(text string) []string {
+	body := strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+	raw := strings.Split(body, "\n")
+
+	for i := range raw {
+		raw[i] = strings.TrimRight(raw[i], " \t\r")
+	}
+	raw[0] = strings.TrimLeft(raw[0], " \t")
+
+	// Декоративный стиль: каждая строка после первой начинается с "*"
+	decorated := len(raw) > 1
+	for _, line := range raw[1:] {
+		if trimmed := strings.TrimLeft(line, " \t"); trimmed != "" && !strings.HasPrefix(trimmed, "*") {
+			decorated = false
+			break
+		}
+	}
+
+	if decorated {
+		for i := 1; i < len(raw); i++ {
+			line := strings.TrimLeft(raw[i], " \t")
+			line = strings.TrimPrefix(line, "*")
+			raw[i] = strings.TrimPrefix(line, " ")
+		}
+	} else {
+		copy(raw[1:], dedent(raw[1:]))
+	}
+
+	// Убираем пустые строки по краям блока
+	for len(raw) > 0 && raw[0] == "" {
+		raw = raw[1:]
+	}
+	for len(raw) > 0 && raw[len(raw)-1] == "" {
+		raw = raw[:len(raw)-1]
+	}
+
+	return raw
+}
+
+// dedent удаляет общий для непустых строк отступ
+func dedent(lines []string) []string {
+	common := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if common < 0 || indent < common {
+			common = indent
+		}
+	}
+
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		switch {
+		case common <= 0:
+			result[i] = line
+		case len(line) >= common:
+			result[i] = line[common:]
+		default:
+			result[i] = strings.TrimLeft(line, " \t")
+		}
+	}
+	return result
+}
+
+// isIndented проверяет, начинается ли непустая строка с отступа
+func isIndented(line string) bool {
+	return strings.TrimSpace(line) != "" && (line[0] == ' ' || line[0] == '\t')
+}
+
+// isFence проверяет, открывает или закрывает ли строка блок кода ``` или ~~~
+func isFence(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// foldLines склеивает многострочное значение аннотации в одну строку
+func foldLines(value string) string {
+	if !strings.Contains(value, "\n") {
+		return value
+	}
+
+	var parts []string
+	for _, line := range strings.Split(value, "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			parts = append(parts, trimmed)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// docBlock накапливает строки аннотации вместе со строками продолжения
+type docBlock struct {
+	head  string
+	lines []string
+}
+
+// value возвращает значение аннотации: первая строка и строки продолжения
+// без общего отступа, разделенные переводом строки
+func (b *docBlock) value() string {
+	lines := b.lines
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return b.head
+	}
+	return b.head + "\n" + strings.Join(dedent(lines), "\n")
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"go/ast"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func commentGroup(texts ...string) *ast.CommentGroup {
+	group := &ast.CommentGroup{}
+	for _, text := range texts {
+		group.List = append(group.List, &ast.Comment{Text: text})
+	}
+	return group
+}
+
+func TestCommentLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		group    *ast.CommentGroup
+		expected []string
+	}{
+		{
+			name:     "line_comments_keep_indent",
+			group:    commentGroup("// first", "//   continued", "//\tcode", "//"),
+			expected: []string{"first", "  continued", "\tcode", ""},
+		},
+		{
+			name:     "decorated_block",
+			group:    commentGroup("/*\n * first\n *   continued\n */"),
+			expected: []string{"first", "  continued"},
+		},
+		{
+			name:     "plain_block",
+			group:    commentGroup("/* first\n    second\n      continued\n*/"),
+			expected: []string{"first", "second", "  continued"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, commentLines(tt.group))
+		})
+	}
+}
+
+func TestParser_parseDocComments_Continuation(t *testing.T) {
+	parser := New()
+	testInfo := &types.TestInfo{Tags: []string{}, Metadata: make(map[string]string)}
+
+	parser.parseDocComments(commentGroup(
+		"// TestLogin checks the login flow.",
+		"// @testcase: Expired password - user with an expired",
+		"//   password is asked to change it",
+		"// @tags: auth,",
+		"//",
+		"//\tsecurity",
+		"// The description continues here.",
+	), testInfo)
+
+	require.Len(t, testInfo.TestCases, 1)
+	assert.Equal(t, "Expired password", testInfo.TestCases[0].Name)
+	assert.Equal(t, "user with an expired password is asked to change it", testInfo.TestCases[0].Description)
+	assert.Contains(t, testInfo.Tags, "security")
+	assert.Equal(t, "TestLogin checks the login flow. The description continues here.", testInfo.Description)
+}
+
+func TestParser_parseDocComments_BlockComment(t *testing.T) {
+	parser := New()
+	testInfo := &types.TestInfo{Tags: []string{}, Metadata: make(map[string]string)}
+
+	parser.parseDocComments(commentGroup(`/*
+TestOrder covers order placement.
+@type: integration
+@testcase: Large order - order with many
+    positions is split into shipments
+*/`), testInfo)
+
+	assert.Equal(t, types.IntegrationTest, testInfo.Type)
+	require.Len(t, testInfo.TestCases, 1)
+	assert.Equal(t, "order with many positions is split into shipments", testInfo.TestCases[0].Description)
+	assert.Equal(t, "TestOrder covers order placement.", testInfo.Description)
+}
+
+func TestParser_parseDocComments_FencedCode(t *testing.T) {
+	parser := New()
+	testInfo := &types.TestInfo{Tags: []string{}, Metadata: make(map[string]string)}
+
+	parser.parseDocComments(commentGroup(
+		"// TestConfig loads a config like:",
+		"// ```yaml",
+		"// server:",
+		"//   port: 8080",
+		"// ```",
+		"// and validates it.",
+	), testInfo)
+
+	assert.Equal(t, "TestConfig loads a config like:\n\n```yaml\nserver:\n  port: 8080\n```\n\nand validates it.",
+		testInfo.Description)
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// maxConstDepth ограничивает глубину разрешения цепочек констант
+const maxConstDepth = 8
+
+// constScope хранит выражения именованных констант файла и тела функции
+type constScope map[string]ast.Expr
+
+// newConstScope собирает константы уровня файла и константы, объявленные в теле функции
+func newConstScope(file *ast.File, body *ast.BlockStmt) constScope {
+	scope := make(constScope)
+
+	if file != nil {
+		for _, decl := range file.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.CONST {
+				scope.collect(gen)
+			}
+		}
+	}
+
+	if body != nil {
+		ast.Inspect(body, func(n ast.Node) bool {
+			if gen, ok := n.(*ast.GenDecl); ok && gen.Tok == token.CONST {
+				scope.collect(gen)
+			}
+			return true
+		})
+	}
+
+	return scope
+}
+
+// collect добавляет константы из декларации const
+func (c constScope) collect(gen *ast.GenDecl) {
+	for _, spec := range gen.Specs {
+		if vs, ok := spec.(*ast.ValueSpec); ok && len(vs.Names) == len(vs.Values) {
+			for i, name := range vs.Names {
+				c[name.Name] = vs.Values[i]
+			}
+		}
+	}
+}
+
+// value вычисляет значение константного выражения: литерала,
+// именованной константы или конкатенации строк
+func (c constScope) value(expr ast.Expr, depth int) (interface{}, bool) {
+	if depth > maxConstDepth {
+		return nil, false
+	}
+
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return basicLitValue(e)
+	case *ast.ParenExpr:
+		return c.value(e.X, depth+1)
+	case *ast.Ident:
+		if value, ok := c[e.Name]; ok {
+			return c.value(value, depth+1)
+		}
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return nil, false
+		}
+		left, okLeft := c.value(e.X, depth+1)
+		right, okRight := c.value(e.Y, depth+1)
+		leftStr, isLeftStr := left.(string)
+		rightStr, isRightStr := right.(string)
+		if okLeft && okRight && isLeftStr && isRightStr {
+			return leftStr + rightStr, true
+		}
+	}
+
+	return nil, false
+}
+
+// stringValue возвращает значение строковой константы
+func (c constScope) stringValue(expr ast.Expr) (string, bool) {
+	value, ok := c.value(expr, 0)
+	str, isString := value.(string)
+	return str, ok && isString
+}
+
+// basicLitValue преобразует литерал в значение Go
+func basicLitValue(lit *ast.BasicLit) (interface{}, bool) {
+	switch lit.Kind {
+	case token.STRING:
+		value, err := strconv.Unquote(lit.Value)
+		return value, err == nil
+	case token.CHAR:
+		value, _, _, err := strconv.UnquoteChar(strings.Trim(lit.Value, "'"), '\'')
+		return value, err == nil
+	case token.INT:
+		value, err := strconv.ParseInt(lit.Value, 0, 64)
+		return value, err == nil
+	case token.FLOAT:
+		value, err := strconv.ParseFloat(lit.Value, 64)
+		return value, err == nil
+	}
+	return nil, false
+}
@@ -0,0 +1,217 @@
+package parser
+
+import (
+	"go/ast"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// Пути импорта, по которым определяются зависимости теста
+const (
+	importHTTPTest       = "net/http/httptest"
+	importSQL            = "database/sql"
+	importOS             = "os"
+	importExec           = "os/exec"
+	importNet            = "net"
+	importTestcontainers = "github.com/testcontainers/testcontainers-go"
+	importDockerClient   = "github.com/docker/docker/client"
+)
+
+// dockerCommands перечисляет исполняемые файлы docker CLI
+var dockerCommands = map[string]bool{
+	"docker":         true,
+	"docker-compose": true,
+	"podman":         true,
+}
+
+// dependencyDetector находит в теле теста обращения к внешним ресурсам
+type dependencyDetector struct {
+	*Source
+}
+
+// detectDependencies находит зависимости теста: httptest, database/sql,
+// переменные окружения, t.TempDir, t.Setenv, testcontainers, docker и net.Dial
+func detectDependencies(src *Source, testInfo *types.TestInfo) {
+	d := &dependencyDetector{Source: src}
+	d.detect(src.Func.Body, testInfo)
+}
+
+// detect обходит тело функции и добавляет найденные зависимости в testInfo
+func (d *dependencyDetector) detect(body *ast.BlockStmt, testInfo *types.TestInfo) {
+	containerFound := false
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if dep, ok := d.callDependency(node); ok {
+				if dep.Kind == types.DependencyContainer {
+					containerFound = true
+				}
+				testInfo.AddDependency(dep)
+			}
+		case *ast.CompositeLit:
+			// testcontainers.ContainerRequest{Image: "postgres:16"}
+			if importPath, name := d.Selector(node.Type); name == "ContainerRequest" && isTestcontainers(importPath) {
+				for _, elt := range node.Elts {
+					kv, ok := elt.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
+					if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Image" {
+						if image, ok := d.consts.stringValue(kv.Value); ok {
+							testInfo.AddDependency(types.Dependency{Kind: types.DependencyContainer, Name: image})
+							containerFound = true
+						}
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	// Использование testcontainers без распознанного образа
+	if !containerFound && d.usesImport(body, isTestcontainers) {
+		testInfo.AddDependency(types.Dependency{Kind: types.DependencyContainer, Name: "testcontainers"})
+	}
+}
+
+// callDependency определяет зависимость по вызову функции
+func (d *dependencyDetector) callDependency(call *ast.CallExpr) (types.Dependency, bool) {
+	importPath, name := d.Selector(call.Fun)
+
+	switch {
+	case importPath == importHTTPTest && strings.HasPrefix(name, "New") && strings.HasSuffix(name, "Server"):
+		return types.Dependency{Kind: types.DependencyHTTPServer, Name: "httptest"}, true
+
+	case importPath == importSQL && name == "Open":
+		driver := "sql"
+		if len(call.Args) > 0 {
+			if value, ok := d.consts.stringValue(call.Args[0]); ok {
+				driver = value
+			}
+		}
+		return types.Dependency{Kind: types.DependencyDatabase, Name: driver}, true
+
+	case importPath == importOS && (name == "Getenv" || name == "LookupEnv"):
+		if key, ok := d.firstString(call); ok {
+			return types.Dependency{Kind: types.DependencyEnv, Name: key}, true
+		}
+
+	case importPath == importExec && (name == "Command" || name == "CommandContext"):
+		args := call.Args
+		if name == "CommandContext" && len(args) > 0 {
+			args = args[1:]
+		}
+		if len(args) > 0 {
+			if command, ok := d.consts.stringValue(args[0]); ok && dockerCommands[path.Base(command)] {
+				return types.Dependency{Kind: types.DependencyDocker, Name: path.Base(command)}, true
+			}
+		}
+
+	case importPath == importDockerClient && strings.HasPrefix(name, "New"):
+		return types.Dependency{Kind: types.DependencyDocker, Name: "docker"}, true
+
+	case importPath == importNet && strings.HasPrefix(name, "Dial"):
+		// net.Dial(network, address), net.DialTimeout(network, address, timeout)
+		if len(call.Args) > 1 {
+			address := "{" + exprString(call.Args[1]) + "}"
+			if value, ok := d.consts.stringValue(call.Args[1]); ok {
+				address = value
+			}
+			return types.Dependency{Kind: types.DependencyNetwork, Name: address}, true
+		}
+
+	case isTestcontainers(importPath) && importPath != importTestcontainers && (name == "Run" || name == "RunContainer"):
+		// Модули testcontainers: postgres.Run(ctx, "postgres:16")
+		module := path.Base(importPath)
+		if len(call.Args) > 1 {
+			if image, ok := d.consts.stringValue(call.Args[1]); ok {
+				module = image
+			}
+		}
+		return types.Dependency{Kind: types.DependencyContainer, Name: module}, true
+	}
+
+	// Методы testing.T: t.TempDir(), t.Setenv("KEY", "value")
+	if importPath == "" {
+		switch name {
+		case "TempDir":
+			if len(call.Args) == 0 {
+				return types.Dependency{Kind: types.DependencyTempDir}, true
+			}
+		case "Setenv":
+			if len(call.Args) == 2 {
+				if key, ok := d.firstString(call); ok {
+					return types.Dependency{Kind: types.DependencySetenv, Name: key}, true
+				}
+			}
+		}
+	}
+
+	return types.Dependency{}, false
+}
+
+// firstString возвращает значение первого аргумента вызова, если это строковая константа
+func (d *dependencyDetector) firstString(call *ast.CallExpr) (string, bool) {
+	if len(call.Args) == 0 {
+		return "", false
+	}
+	return d.consts.stringValue(call.Args[0])
+}
+
+// usesImport проверяет, обращается ли тело функции к пакету, удовлетворяющему условию
+func (d *dependencyDetector) usesImport(body *ast.BlockStmt, match func(string) bool) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok && match(d.imports[ident.Name]) {
+				found = true
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// isTestcontainers проверяет, относится ли путь импорта к testcontainers-go
+func isTestcontainers(importPath string) bool {
+	return importPath == importTestcontainers || strings.HasPrefix(importPath, importTestcontainers+"/")
+}
+
+// importNames возвращает соответствие локальных имен пакетов путям импорта файла
+func importNames(file *ast.File) map[string]string {
+	names := make(map[string]string)
+	if file == nil {
+		return names
+	}
+
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := path.Base(importPath)
+		// Модули с суффиксом версии: github.com/foo/bar/v2 → bar
+		if strings.HasPrefix(name, "v") && len(name) > 1 && strings.Trim(name[1:], "0123456789") == "" {
+			name = path.Base(path.Dir(importPath))
+		}
+		name = strings.TrimSuffix(name, "-go")
+
+		if spec.Name != nil {
+			if spec.Name.Name == "_" || spec.Name.Name == "." {
+				continue
+			}
+			name = spec.Name.Name
+		}
+		names[name] = importPath
+	}
+
+	return names
+}
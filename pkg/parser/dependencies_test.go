@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func TestDetectDependencies(t *testing.T) {
+	testCode := `package example
+
+import (
+	"context"
+	stdsql "database/sql"
+	"net"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+const brokerAddr = "localhost:9092"
+
+func TestOrders(t *testing.T) {
+	srv := httptest.NewServer(nil)
+	defer srv.Close()
+
+	db, _ := stdsql.Open("postgres", os.Getenv("DATABASE_URL"))
+	defer db.Close()
+
+	dir := t.TempDir()
+	t.Setenv("APP_DIR", dir)
+
+	conn, _ := net.Dial("tcp", brokerAddr)
+	defer conn.Close()
+
+	_ = exec.Command("docker", "compose", "up").Run()
+
+	ctx := context.Background()
+	req := testcontainers.ContainerRequest{Image: "postgres:16"}
+	_, _ = testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{ContainerRequest: req})
+	_, _ = redis.Run(ctx, "redis:7")
+}
+
+func TestPlain(t *testing.T) {
+	_ = httptest.NewRecorder()
+}
+`
+
+	tests := parseTestSource(t, testCode)
+
+	assert.Equal(t, []types.Dependency{
+		{Kind: types.DependencyHTTPServer, Name: "httptest"},
+		{Kind: types.DependencyDatabase, Name: "postgres"},
+		{Kind: types.DependencyEnv, Name: "DATABASE_URL"},
+		{Kind: types.DependencyTempDir},
+		{Kind: types.DependencySetenv, Name: "APP_DIR"},
+		{Kind: types.DependencyNetwork, Name: "localhost:9092"},
+		{Kind: types.DependencyDocker, Name: "docker"},
+		{Kind: types.DependencyContainer, Name: "postgres:16"},
+		{Kind: types.DependencyContainer, Name: "redis:7"},
+	}, tests["TestOrders"].Dependencies)
+
+	assert.Empty(t, tests["TestPlain"].Dependencies)
+}
+
+func TestImportNames(t *testing.T) {
+	src := `package example
+
+import (
+	"os"
+	stdsql "database/sql"
+	_ "github.com/lib/pq"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/jackc/pgx/v5"
+)
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "example_test.go", src, parser.ImportsOnly)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"os":             "os",
+		"stdsql":         "database/sql",
+		"testcontainers": "github.com/testcontainers/testcontainers-go",
+		"pgx":            "github.com/jackc/pgx/v5",
+	}, importNames(file))
+	assert.Empty(t, importNames((*ast.File)(nil)))
+}
@@ -0,0 +1,170 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// Annotation представляет аннотацию doc-комментария вида "@name: value"
+type Annotation struct {
+	// Name - имя аннотации без @, например "type" или "jira"
+	Name string
+	// Value - значение аннотации; строки продолжения склеены через пробел
+	Value string
+	// Raw - значение аннотации со строками продолжения без изменений
+	Raw string
+}
+
+// ParseAnnotation разбирает текст аннотации "@name: value". Строки продолжения
+// передаются в Raw как есть. Текст без @ или без двоеточия аннотацией не считается.
+func ParseAnnotation(text string) (Annotation, bool) {
+	if !strings.HasPrefix(text, "@") {
+		return Annotation{}, false
+	}
+
+	name, raw, ok := strings.Cut(strings.TrimPrefix(text, "@"), ":")
+	if !ok || strings.Contains(name, "\n") {
+		return Annotation{}, false
+	}
+
+	return Annotation{
+		Name:  strings.TrimSpace(name),
+		Value: strings.TrimSpace(foldLines(raw)),
+		Raw:   raw,
+	}, true
+}
+
+// Source содержит исходный код тест-функции и файла, в котором она объявлена
+type Source struct {
+	FileSet *token.FileSet
+	File    *ast.File
+	Func    *ast.FuncDecl
+
+	imports map[string]string
+	consts  constScope
+}
+
+// newSource подготавливает общие данные файла и функции для экстракторов
+func newSource(fileSet *token.FileSet, file *ast.File, fn *ast.FuncDecl) *Source {
+	return &Source{
+		FileSet: fileSet,
+		File:    file,
+		Func:    fn,
+		imports: importNames(file),
+		consts:  newConstScope(file, fn.Body),
+	}
+}
+
+// Imports возвращает соответствие локальных имен пакетов путям импорта файла
+func (s *Source) Imports() map[string]string {
+	return s.imports
+}
+
+// Selector возвращает путь импорта и имя для выражения вида pkg.Name.
+// Для методов значений (t.TempDir) путь импорта пустой.
+func (s *Source) Selector(expr ast.Expr) (string, string) {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return "", ""
+	}
+	if ident, ok := sel.X.(*ast.Ident); ok {
+		return s.imports[ident.Name], sel.Sel.Name
+	}
+	return "", sel.Sel.Name
+}
+
+// StringValue вычисляет значение строковой константы: литерала, константы
+// файла или функции либо их конкатенации
+func (s *Source) StringValue(expr ast.Expr) (string, bool) {
+	return s.consts.stringValue(expr)
+}
+
+// Extractor извлекает информацию о тесте из аннотаций и тела тест-функции.
+// Встроенные аннотации и анализ тела реализованы как экстракторы по умолчанию,
+// пользовательские экстракторы подключаются через Parser.Register.
+type Extractor interface {
+	// ExtractDoc обрабатывает аннотацию и возвращает true, если она распознана.
+	// Распознанная аннотация не передается следующим экстракторам.
+	ExtractDoc(a Annotation, testInfo *types.TestInfo) bool
+	// ExtractBody анализирует тело тест-функции
+	ExtractBody(src *Source, testInfo *types.TestInfo)
+}
+
+// DocExtractorFunc позволяет использовать функцию как экстрактор аннотаций
+type DocExtractorFunc func(a Annotation, testInfo *types.TestInfo) bool
+
+// ExtractDoc вызывает f(a, testInfo)
+func (f DocExtractorFunc) ExtractDoc(a Annotation, testInfo *types.TestInfo) bool {
+	return f(a, testInfo)
+}
+
+// ExtractBody ничего не делает
+func (f DocExtractorFunc) ExtractBody(*Source, *types.TestInfo) {}
+
+// BodyExtractorFunc позволяет использовать функцию как экстрактор тела тест-функции
+type BodyExtractorFunc func(src *Source, testInfo *types.TestInfo)
+
+// ExtractDoc не распознает аннотаций
+func (f BodyExtractorFunc) ExtractDoc(Annotation, *types.TestInfo) bool {
+	return false
+}
+
+// ExtractBody вызывает f(src, testInfo)
+func (f BodyExtractorFunc) ExtractBody(src *Source, testInfo *types.TestInfo) {
+	f(src, testInfo)
+}
+
+// defaultExtractors возвращает встроенные экстракторы в порядке применения.
+// Произвольные метаданные обрабатываются последними.
+func defaultExtractors() []Extractor {
+	return []Extractor{
+		DocExtractorFunc(extractBuiltinAnnotation),
+		BodyExtractorFunc(detectSkips),
+		BodyExtractorFunc(detectDependencies),
+		BodyExtractorFunc(detectTraits),
+		BodyExtractorFunc(fingerprintBody),
+		DocExtractorFunc(extractMetadata),
+	}
+}
+
+// Register подключает пользовательские экстракторы. Аннотации передаются
+// пользовательским экстракторам раньше встроенных, поэтому они могут
+// переопределить обработку любой аннотации. Тело функции анализируется
+// пользовательскими экстракторами после встроенных, так что им доступны
+// уже найденные пропуски, зависимости и особенности выполнения.
+func (p *Parser) Register(extractors ...Extractor) {
+	p.extractors = append(p.extractors, extractors...)
+}
+
+// parseAnnotation передает аннотацию экстракторам до первого, распознавшего ее
+func (p *Parser) parseAnnotation(text string, testInfo *types.TestInfo) {
+	annotation, ok := ParseAnnotation(text)
+	if !ok {
+		return
+	}
+
+	for _, extractor := range p.extractors {
+		if extractor.ExtractDoc(annotation, testInfo) {
+			return
+		}
+	}
+	for _, extractor := range p.defaults {
+		if extractor.ExtractDoc(annotation, testInfo) {
+			return
+		}
+	}
+}
+
+// analyzeTestBody анализирует тело тест-функции встроенными, а затем пользовательскими экстракторами
+func (p *Parser) analyzeTestBody(fn *ast.FuncDecl, file *ast.File, testInfo *types.TestInfo) {
+	src := newSource(p.fileSet, file, fn)
+	for _, extractor := range p.defaults {
+		extractor.ExtractBody(src, testInfo)
+	}
+	for _, extractor := range p.extractors {
+		extractor.ExtractBody(src, testInfo)
+	}
+}
@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func TestParseAnnotation(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected Annotation
+		ok       bool
+	}{
+		{"simple", "@type: unit", Annotation{Name: "type", Value: "unit", Raw: " unit"}, true},
+		{"multiline", "@input: {\n  \"a\": 1\n}", Annotation{Name: "input", Value: `{ "a": 1 }`, Raw: " {\n  \"a\": 1\n}"}, true},
+		{"value_with_colon", "@jira: https://jira/PAY-1", Annotation{Name: "jira", Value: "https://jira/PAY-1", Raw: " https://jira/PAY-1"}, true},
+		{"no_colon", "@deprecated", Annotation{}, false},
+		{"not_annotation", "plain text: value", Annotation{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotation, ok := ParseAnnotation(tt.text)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, annotation)
+		})
+	}
+}
+
+func TestParser_Register(t *testing.T) {
+	testCode := `package example
+
+import "testing"
+
+// TestCharge проверяет списание
+// @type: unit
+// @jira: PAY-1, PAY-2
+// @component: billing
+func TestCharge(t *testing.T) {
+	assertx.Equal(t, 1, 1)
+	assertx.NoError(t, nil)
+}
+
+// @type: integration
+func Test_badName(t *testing.T) {}
+`
+	testFile := filepath.Join(t.TempDir(), "custom_test.go")
+	require.NoError(t, os.WriteFile(testFile, []byte(testCode), 0644))
+
+	// @jira переопределяет обработку метаданных, @type - встроенную аннотацию
+	jira := DocExtractorFunc(func(a Annotation, testInfo *types.TestInfo) bool {
+		if a.Name != "jira" {
+			return false
+		}
+		testInfo.Issues = appendUnique(testInfo.Issues, splitList(a.Value)...)
+		return true
+	})
+	typeOverride := DocExtractorFunc(func(a Annotation, testInfo *types.TestInfo) bool {
+		if a.Name != "type" || a.Value != "integration" {
+			return false
+		}
+		testInfo.Type = types.E2ETest
+		return true
+	})
+
+	// Подсчет проверок собственной библиотеки и правило именования тестов
+	body := BodyExtractorFunc(func(src *Source, testInfo *types.TestInfo) {
+		assertions := 0
+		ast.Inspect(src.Func.Body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+					if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "assertx" {
+						assertions++
+					}
+				}
+			}
+			return true
+		})
+		testInfo.Metadata["assertions"] = strings.Repeat("✓", assertions)
+		if strings.Contains(testInfo.Name, "_") {
+			testInfo.Tags = append(testInfo.Tags, "naming-violation")
+		}
+	})
+
+	p := New()
+	p.Register(jira, typeOverride, body)
+
+	tests, err := p.ParseFile(testFile)
+	require.NoError(t, err)
+	require.Len(t, tests, 2)
+
+	charge := tests[0]
+	assert.Equal(t, types.UnitTest, charge.Type)
+	assert.Equal(t, []string{"PAY-1", "PAY-2"}, charge.Issues)
+	assert.Equal(t, map[string]string{"component": "billing", "assertions": "✓✓"}, charge.Metadata)
+	assert.Empty(t, charge.Tags)
+
+	bad := tests[1]
+	assert.Equal(t, types.E2ETest, bad.Type)
+	assert.Equal(t, []string{"naming-violation"}, bad.Tags)
+}
+
+func TestParser_Register_BodyAfterDefaults(t *testing.T) {
+	testCode := `package example
+
+import "testing"
+
+func TestSkipped(t *testing.T) {
+	t.Skip("broken")
+}
+`
+	testFile := filepath.Join(t.TempDir(), "order_test.go")
+	require.NoError(t, os.WriteFile(testFile, []byte(testCode), 0644))
+
+	var seenSkipped bool
+	p := New()
+	p.Register(BodyExtractorFunc(func(src *Source, testInfo *types.TestInfo) {
+		seenSkipped = testInfo.Skipped
+	}))
+
+	_, err := p.ParseFile(testFile)
+	require.NoError(t, err)
+	assert.True(t, seenSkipped)
+}
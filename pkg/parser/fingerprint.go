@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"bytes"
+	"go/printer"
+	"go/scanner"
+	"go/token"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// fingerprintBody вычисляет сигнатуру тела теста для поиска переименований.
+// Тело печатается без комментариев в каноническом форматировании, поэтому
+// сигнатура не зависит от отступов и комментариев.
+func fingerprintBody(src *Source, testInfo *types.TestInfo) {
+	if src.Func.Body == nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, src.FileSet, src.Func.Body); err != nil {
+		return
+	}
+
+	// Внешние фигурные скобки не несут информации: пустые тела не должны совпадать
+	tokens := bodyTokens(buf.Bytes())
+	if len(tokens) < 2 {
+		return
+	}
+	testInfo.Fingerprint = types.NewFingerprint(tokens[1 : len(tokens)-1])
+}
+
+// bodyTokens разбивает исходный код на токены Go
+func bodyTokens(code []byte) []string {
+	fileSet := token.NewFileSet()
+	file := fileSet.AddFile("", fileSet.Base(), len(code))
+
+	var s scanner.Scanner
+	s.Init(file, code, nil, 0)
+
+	var tokens []string
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return tokens
+		}
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+		if lit != "" {
+			tokens = append(tokens, lit)
+		} else {
+			tokens = append(tokens, tok.String())
+		}
+	}
+}
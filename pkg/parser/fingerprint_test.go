@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprintBody(t *testing.T) {
+	tests := parseTestSource(t, `package example
+
+import "testing"
+
+func TestOriginal(t *testing.T) {
+	got := sum(1, 2)
+	if got != 3 {
+		t.Fatalf("got %d", got)
+	}
+}
+
+// Тот же код с другими комментариями и форматированием
+func TestRenamed(t *testing.T) {
+	// сумма
+	got := sum(1,   2)
+	if got != 3 { t.Fatalf("got %d", got) }
+}
+
+func TestOther(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+	resp, err := srv.Client().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+func TestEmpty(t *testing.T) {}
+`)
+
+	original := tests["TestOriginal"].Fingerprint
+	require.NotEmpty(t, original)
+	assert.Equal(t, 1.0, original.Similarity(tests["TestRenamed"].Fingerprint))
+	assert.Less(t, original.Similarity(tests["TestOther"].Fingerprint), 0.5)
+	assert.Empty(t, tests["TestEmpty"].Fingerprint)
+}
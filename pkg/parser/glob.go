@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"path"
+	"strings"
+)
+
+// matchGlob сопоставляет путь со слэшами с glob-шаблоном.
+// Помимо синтаксиса path.Match поддерживается сегмент "**",
+// соответствующий любому количеству директорий (в том числе нулю).
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+// matchSegments рекурсивно сопоставляет сегменты шаблона и пути
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], name[0]); !matched {
+			return false
+		}
+
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+
+	return len(name) == 0
+}
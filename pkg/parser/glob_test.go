@@ -0,0 +1,31 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"exact", "pkg/parser", "pkg/parser", true},
+		{"star_in_segment", "pkg/*", "pkg/parser", true},
+		{"star_does_not_cross_slash", "pkg/*", "pkg/parser/parser_test.go", false},
+		{"double_star_any_depth", "pkg/**/*_test.go", "pkg/a/b/c_test.go", true},
+		{"double_star_zero_dirs", "pkg/**/*_test.go", "pkg/c_test.go", true},
+		{"leading_double_star", "**/vendor", "a/b/vendor", true},
+		{"trailing_double_star", "vendor/**", "vendor/x/y.go", true},
+		{"no_match", "internal/**", "pkg/parser.go", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchGlob(tt.pattern, tt.path))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+	"path"
+	"strings"
+)
+
+// Файлы правил игнорирования, читаемые в каждой директории обхода
+const (
+	gitignoreFile     = ".gitignore"
+	testdocignoreFile = ".testdocignore"
+)
+
+// skipDirs - директории, в которые обход никогда не спускается
+var skipDirs = map[string]bool{
+	".git":         true,
+	".hg":          true,
+	".svn":         true,
+	"vendor":       true,
+	"node_modules": true,
+}
+
+// ignoreRule - правило файла .gitignore или .testdocignore
+type ignoreRule struct {
+	// base - директория файла правил относительно корня обхода ("" для корня)
+	base     string
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// ignoreRules - правила игнорирования в порядке чтения; побеждает последнее совпавшее
+type ignoreRules []ignoreRule
+
+// parseIgnore разбирает файл правил в синтаксисе .gitignore. Правила
+// действуют внутри директории base (путь относительно корня обхода).
+func parseIgnore(r io.Reader, base string) (ignoreRules, error) {
+	var rules ignoreRules
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		// Шаблон со слэшем в начале или середине задается относительно директории файла
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+
+		rule.pattern = line
+		rules = append(rules, rule)
+	}
+
+	return rules, scanner.Err()
+}
+
+// ignored проверяет, исключен ли путь rel (относительно корня обхода)
+func (rules ignoreRules) ignored(rel string, isDir bool) bool {
+	ignored := false
+	for _, rule := range rules {
+		if rule.matches(rel, isDir) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// matches проверяет, совпадает ли правило с путем
+func (rule ignoreRule) matches(rel string, isDir bool) bool {
+	if rule.dirOnly && !isDir {
+		return false
+	}
+
+	if rule.base != "" {
+		if !strings.HasPrefix(rel, rule.base+"/") {
+			return false
+		}
+		rel = strings.TrimPrefix(rel, rule.base+"/")
+	}
+
+	if rule.anchored {
+		return matchGlob(rule.pattern, rel)
+	}
+	matched, _ := path.Match(rule.pattern, path.Base(rel))
+	return matched
+}
+
+// matchPattern сопоставляет путь rel (относительно корня обхода) с паттерном
+// include_patterns или exclude_patterns. Паттерн без слэша сравнивается
+// с именем файла или директории, паттерн со слэшем - с полным путем,
+// "**" соответствует любому количеству директорий.
+func matchPattern(pattern, rel string) bool {
+	pattern = strings.TrimPrefix(strings.TrimPrefix(pattern, "./"), "/")
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(rel))
+		return matched
+	}
+	return matchGlob(pattern, rel)
+}
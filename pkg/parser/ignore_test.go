@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIgnore(t *testing.T) {
+	rules, err := parseIgnore(strings.NewReader(`# комментарий
+*.log
+/build
+generated/
+docs/*.md
+!keep.log
+\#hash
+`), "")
+	require.NoError(t, err)
+	require.Len(t, rules, 6)
+
+	assert.Equal(t, ignoreRule{pattern: "*.log"}, rules[0])
+	assert.Equal(t, ignoreRule{pattern: "build", anchored: true}, rules[1])
+	assert.Equal(t, ignoreRule{pattern: "generated", dirOnly: true}, rules[2])
+	assert.Equal(t, ignoreRule{pattern: "docs/*.md", anchored: true}, rules[3])
+	assert.Equal(t, ignoreRule{pattern: "keep.log", negate: true}, rules[4])
+	assert.Equal(t, ignoreRule{pattern: "#hash"}, rules[5])
+}
+
+func TestIgnoreRules_ignored(t *testing.T) {
+	root, err := parseIgnore(strings.NewReader("*.log\n!keep.log\n/build\ngenerated/\n"), "")
+	require.NoError(t, err)
+	nested, err := parseIgnore(strings.NewReader("legacy_test.go\n/local\n"), "internal/api")
+	require.NoError(t, err)
+	rules := append(root, nested...)
+
+	tests := []struct {
+		rel      string
+		isDir    bool
+		expected bool
+	}{
+		{"debug.log", false, true},
+		{"pkg/debug.log", false, true},
+		{"pkg/keep.log", false, false},
+		{"build", true, true},
+		{"pkg/build", true, false},
+		{"generated", true, true},
+		{"pkg/generated", true, true},
+		{"generated", false, false},
+		{"internal/api/legacy_test.go", false, true},
+		{"internal/api/v2/legacy_test.go", false, true},
+		{"internal/web/legacy_test.go", false, false},
+		{"internal/api/local", true, true},
+		{"internal/api/v2/local", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rel, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rules.ignored(tt.rel, tt.isDir))
+		})
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		rel      string
+		expected bool
+	}{
+		{"*_test.go", "pkg/api/handler_test.go", true},
+		{"bench_*", "pkg/bench_test.go", true},
+		{"vendor/**", "vendor", true},
+		{"vendor/**", "vendor/github.com/x/x_test.go", true},
+		{"vendor/**", "pkg/vendor/x_test.go", false},
+		{"**/testdata/**", "pkg/api/testdata/x_test.go", true},
+		{"internal/legacy/**", "internal/legacy/old/old_test.go", true},
+		{"internal/legacy/**", "internal/legacyx/old_test.go", false},
+		{"/internal/*_test.go", "internal/a_test.go", true},
+		{"./internal/*_test.go", "internal/sub/a_test.go", false},
+		{"pkg/**/*_e2e_test.go", "pkg/a/b/login_e2e_test.go", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.rel, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchPattern(tt.pattern, tt.rel))
+		})
+	}
+}
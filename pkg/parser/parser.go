@@ -5,40 +5,56 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"io/fs"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
-	"time"
 
 	"github.com/seblex/testdoc/pkg/types"
 )
 
 // Parser анализирует Go файлы с тестами
 type Parser struct {
-	fileSet *token.FileSet
+	fileSet    *token.FileSet
+	defaults   []Extractor
+	extractors []Extractor
 }
 
 // New создает новый парсер тестов
 func New() *Parser {
 	return &Parser{
-		fileSet: token.NewFileSet(),
+		fileSet:  token.NewFileSet(),
+		defaults: defaultExtractors(),
 	}
 }
 
 // ParseFile анализирует один тест-файл и возвращает информацию о тестах
 func (p *Parser) ParseFile(filename string) ([]types.TestInfo, error) {
-	src, err := parser.ParseFile(p.fileSet, filename, nil, parser.ParseComments)
+	return p.parse(filename, nil)
+}
+
+// ParseSource анализирует исходный код тест-файла из памяти, например буфера
+// редактора. name используется в позициях и поле File тестов.
+func (p *Parser) ParseSource(name string, src []byte) ([]types.TestInfo, error) {
+	if src == nil {
+		src = []byte{}
+	}
+	return p.parse(name, src)
+}
+
+// parse анализирует тест-файл. Если src равен nil, файл читается с диска.
+func (p *Parser) parse(filename string, src interface{}) ([]types.TestInfo, error) {
+	file, err := parser.ParseFile(p.fileSet, filename, src, parser.ParseComments)
 	if err != nil {
 		return nil, err
 	}
 
 	var tests []types.TestInfo
 
-	for _, decl := range src.Decls {
+	for _, decl := range file.Decls {
 		if fn, ok := decl.(*ast.FuncDecl); ok {
 			if p.isTestFunction(fn.Name.Name) {
-				testInfo := p.parseTestFunction(fn, src, filename)
+				testInfo := p.parseTestFunction(fn, file, filename)
 				tests = append(tests, testInfo)
 			}
 		}
@@ -47,25 +63,90 @@ func (p *Parser) ParseFile(filename string) ([]types.TestInfo, error) {
 	return tests, nil
 }
 
+// tree описывает дерево файлов для анализа
+type tree struct {
+	fsys fs.FS
+	// root - директория fsys, с которой начинается обход
+	root string
+	// path возвращает путь файла fsys для вывода и паттернов включения
+	path func(name string) string
+	// owners - правила CODEOWNERS, nil если файл не найден
+	owners *CodeOwners
+	// ownerPath возвращает путь файла fsys относительно корня репозитория
+	ownerPath func(name string) string
+	// resolve возвращает путь директории fsys с раскрытыми символическими
+	// ссылками для защиты от циклов; nil, если fsys не отображается на диск
+	resolve func(name string) (string, error)
+}
+
 // ParseDirectory рекурсивно анализирует директорию и возвращает результат парсинга
 func (p *Parser) ParseDirectory(rootPath string, config *types.Config) (*types.ParseResult, error) {
-	packages := make(map[string]*types.PackageInfo)
+	if rootPath == "" {
+		rootPath = "."
+	}
 
-	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+	owners, ownersRoot, err := p.loadCodeOwners(rootPath, config)
+	if err != nil {
+		return nil, err
+	}
 
-		if !p.isTestFile(path) {
-			return nil
-		}
+	// Одиночный файл обходится из его директории
+	dir, root := rootPath, "."
+	if info, err := os.Stat(rootPath); err == nil && !info.IsDir() {
+		dir, root = filepath.Dir(rootPath), filepath.Base(rootPath)
+	}
+
+	osPath := func(name string) string {
+		return filepath.Join(dir, filepath.FromSlash(name))
+	}
+
+	return p.parseTree(tree{
+		fsys:   os.DirFS(dir),
+		root:   root,
+		path:   osPath,
+		owners: owners,
+		ownerPath: func(name string) string {
+			return p.relativePath(ownersRoot, osPath(name))
+		},
+		resolve: func(name string) (string, error) {
+			return filepath.EvalSymlinks(osPath(name))
+		},
+	}, config)
+}
+
+// ParseFS рекурсивно анализирует тест-файлы файловой системы fsys, начиная
+// с директории root: встроенных testdata (embed.FS), zip архивов, наложений
+// в памяти или дерева git ревизии. CODEOWNERS ищется в корне fsys, пути
+// пакетов в результате задаются в формате fs.FS.
+func (p *Parser) ParseFS(fsys fs.FS, root string, config *types.Config) (*types.ParseResult, error) {
+	owners, err := p.loadCodeOwnersFS(fsys, config)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := func(name string) string { return name }
+	return p.parseTree(tree{
+		fsys:      fsys,
+		root:      root,
+		path:      identity,
+		owners:    owners,
+		ownerPath: identity,
+	}, config)
+}
+
+// parseTree рекурсивно анализирует тест-файлы дерева
+func (p *Parser) parseTree(t tree, config *types.Config) (*types.ParseResult, error) {
+	packages := make(map[string]*types.PackageInfo)
 
-		// Проверяем паттерны исключения и включения
-		if p.shouldExcludeFile(path, config) {
+	err := p.walk(t, config, func(name, rel string, config *types.Config) error {
+		path := t.path(name)
+
+		src, err := fs.ReadFile(t.fsys, name)
+		if err != nil {
 			return nil
 		}
 
-		tests, err := p.ParseFile(path)
+		tests, err := p.ParseSource(path, src)
 		if err != nil {
 			// Логируем предупреждение, но продолжаем
 			return nil
@@ -77,6 +158,11 @@ func (p *Parser) ParseDirectory(rootPath string, config *types.Config) (*types.P
 				test.Type = types.UnitTest
 			}
 
+			// Владельцы из CODEOWNERS, если не заданы аннотацией @owner
+			if len(test.Owners) == 0 && t.owners != nil {
+				test.Owners = t.owners.Owners(t.ownerPath(name))
+			}
+
 			// Пропускаем пропущенные тесты, если настроено
 			if test.Skipped && !config.IncludeSkipped {
 				continue
@@ -122,6 +208,43 @@ func (p *Parser) ParseDirectory(rootPath string, config *types.Config) (*types.P
 	return result, nil
 }
 
+// loadCodeOwners загружает CODEOWNERS из конфигурации или ищет его вверх от rootPath
+func (p *Parser) loadCodeOwners(rootPath string, config *types.Config) (*CodeOwners, string, error) {
+	filename, root := config.CodeOwnersFile, ""
+	if filename != "" {
+		root = CodeOwnersRoot(filename)
+	} else {
+		filename, root = FindCodeOwners(rootPath)
+		if filename == "" {
+			return nil, "", nil
+		}
+	}
+
+	owners, err := LoadCodeOwners(filename)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return owners, root, nil
+}
+
+// relativePath возвращает путь файла относительно корня репозитория
+func (p *Parser) relativePath(root, path string) string {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return path
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(absRoot, absPath)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
 // isTestFunction проверяет, является ли функция тест-функцией
 func (p *Parser) isTestFunction(name string) bool {
 	return strings.HasPrefix(name, "Test") ||
@@ -134,13 +257,16 @@ func (p *Parser) isTestFile(filename string) bool {
 	return strings.HasSuffix(filename, "_test.go")
 }
 
-// shouldExcludeFile проверяет, нужно ли исключить файл
+// shouldExcludeFile проверяет, нужно ли исключить файл. path задается
+// относительно корня обхода, см. matchPattern.
 func (p *Parser) shouldExcludeFile(path string, config *types.Config) bool {
+	path = filepath.ToSlash(path)
+
 	// Проверяем паттерны включения
 	if len(config.IncludePatterns) > 0 {
 		included := false
 		for _, pattern := range config.IncludePatterns {
-			if matched, _ := filepath.Match(pattern, filepath.Base(path)); matched {
+			if matchPattern(pattern, path) {
 				included = true
 				break
 			}
@@ -152,7 +278,7 @@ func (p *Parser) shouldExcludeFile(path string, config *types.Config) bool {
 
 	// Проверяем паттерны исключения
 	for _, pattern := range config.ExcludePatterns {
-		if matched, _ := filepath.Match(pattern, filepath.Base(path)); matched {
+		if matchPattern(pattern, path) {
 			return true
 		}
 	}
@@ -160,6 +286,17 @@ func (p *Parser) shouldExcludeFile(path string, config *types.Config) bool {
 	return false
 }
 
+// shouldExcludeDir проверяет, исключена ли директория паттернами исключения.
+// В исключенную директорию обход не спускается.
+func (p *Parser) shouldExcludeDir(path string, config *types.Config) bool {
+	for _, pattern := range config.ExcludePatterns {
+		if matchPattern(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
 // parseTestFunction извлекает информацию о тест-функции
 func (p *Parser) parseTestFunction(fn *ast.FuncDecl, file *ast.File, filename string) types.TestInfo {
 	position := p.fileSet.Position(fn.Pos())
@@ -178,151 +315,129 @@ func (p *Parser) parseTestFunction(fn *ast.FuncDecl, file *ast.File, filename st
 		p.parseDocComments(fn.Doc, &testInfo)
 	}
 
-	// Анализируем тело функции для поиска skip-ов
+	// Анализируем тело функции: skip-ы, внешние зависимости, особенности выполнения
 	if fn.Body != nil {
-		p.analyzeTestBody(fn.Body, &testInfo)
+		p.analyzeTestBody(fn, file, &testInfo)
 	}
 
 	return testInfo
 }
 
-// parseDocComments анализирует doc-комментарии функции
+// parseDocComments анализирует doc-комментарии функции.
+// Строки с отступом после аннотации продолжают ее значение (в том числе после
+// пустой строки, которую вставляет gofmt), блоки кода ``` сохраняются в описании
+// дословно.
 func (p *Parser) parseDocComments(docGroup *ast.CommentGroup, testInfo *types.TestInfo) {
-	fullComment := ""
-
-	for _, comment := range docGroup.List {
-		line := strings.TrimPrefix(comment.Text, "//")
-		line = strings.TrimPrefix(line, "/*")
-		line = strings.TrimSuffix(line, "*/")
-		line = strings.TrimSpace(line)
-
-		if line == "" {
-			continue
+	var (
+		description string
+		annotation  *docBlock
+		fence       []string
+		inFence     bool
+		blank       bool
+	)
+
+	flush := func() {
+		if annotation != nil {
+			p.parseAnnotation(annotation.value(), testInfo)
+			annotation = nil
 		}
+	}
 
-		// Проверяем на аннотации
-		if strings.HasPrefix(line, "@") {
-			p.parseAnnotation(line, testInfo)
-		} else {
-			if fullComment != "" {
-				fullComment += " "
-			}
-			fullComment += line
+	addText := func(text string) {
+		if description != "" && !strings.HasSuffix(description, "\n") {
+			description += " "
 		}
+		description += text
 	}
 
-	testInfo.Description = fullComment
-}
-
-// parseAnnotation парсит аннотации в комментариях
-func (p *Parser) parseAnnotation(line string, testInfo *types.TestInfo) {
-	// @type: unit|integration|functional|e2e|performance|security|regression|smoke
-	if strings.HasPrefix(line, "@type:") {
-		typeStr := strings.TrimSpace(strings.TrimPrefix(line, "@type:"))
-		testInfo.Type = types.TestType(typeStr)
-		return
+	addBlock := func(lines []string) {
+		description = strings.TrimRight(description, " ") + "\n\n" + strings.Join(dedent(lines), "\n") + "\n\n"
 	}
 
-	// @author: имя автора
-	if strings.HasPrefix(line, "@author:") {
-		testInfo.Author = strings.TrimSpace(strings.TrimPrefix(line, "@author:"))
-		return
-	}
+	for _, line := range commentLines(docGroup) {
+		trimmed := strings.TrimSpace(line)
 
-	// @tags: tag1,tag2,tag3
-	if strings.HasPrefix(line, "@tags:") {
-		tagsStr := strings.TrimSpace(strings.TrimPrefix(line, "@tags:"))
-		tags := strings.Split(tagsStr, ",")
-		for _, tag := range tags {
-			testInfo.Tags = append(testInfo.Tags, strings.TrimSpace(tag))
+		// Внутри блока кода строки сохраняются дословно
+		if inFence {
+			if annotation != nil {
+				annotation.lines = append(annotation.lines, line)
+			} else {
+				fence = append(fence, line)
+			}
+			if isFence(line) {
+				inFence = false
+				if annotation == nil {
+					addBlock(fence)
+					fence = nil
+				}
+			}
+			continue
 		}
-		return
-	}
-
-	// @testcase: название кейса - описание
-	if strings.HasPrefix(line, "@testcase:") {
-		caseStr := strings.TrimSpace(strings.TrimPrefix(line, "@testcase:"))
-		parts := strings.SplitN(caseStr, "-", 2)
 
-		testCase := types.TestCase{
-			Name: strings.TrimSpace(parts[0]),
+		if trimmed == "" {
+			blank = true
+			continue
 		}
 
-		if len(parts) > 1 {
-			testCase.Description = strings.TrimSpace(parts[1])
+		// Строка продолжения: отступ или блок кода сразу после аннотации.
+		// Строка, начинающаяся с @, всегда открывает новую аннотацию.
+		continuation := isIndented(line) || (isFence(line) && !blank)
+		if annotation != nil && continuation && !strings.HasPrefix(trimmed, "@") {
+			if blank && len(annotation.lines) > 0 {
+				annotation.lines = append(annotation.lines, "")
+			}
+			annotation.lines = append(annotation.lines, line)
+			inFence = isFence(line)
+			blank = false
+			continue
 		}
 
-		testInfo.TestCases = append(testInfo.TestCases, testCase)
-		return
-	}
-
-	// @step: действие - ожидаемый результат
-	if strings.HasPrefix(line, "@step:") {
-		stepStr := strings.TrimSpace(strings.TrimPrefix(line, "@step:"))
-		parts := strings.SplitN(stepStr, "-", 2)
+		blank = false
+		flush()
 
-		step := types.Step{
-			Action: strings.TrimSpace(parts[0]),
+		// Проверяем на аннотации
+		if strings.HasPrefix(trimmed, "@") {
+			annotation = &docBlock{head: trimmed}
+			continue
 		}
 
-		if len(parts) > 1 {
-			step.Expected = strings.TrimSpace(parts[1])
+		if isFence(line) {
+			inFence = true
+			fence = []string{line}
+			continue
 		}
 
-		// Добавляем к последнему тест-кейсу
-		if len(testInfo.TestCases) > 0 {
-			lastIndex := len(testInfo.TestCases) - 1
-			testInfo.TestCases[lastIndex].Steps = append(testInfo.TestCases[lastIndex].Steps, step)
-		}
-		return
+		addText(trimmed)
 	}
 
-	// @created: дата создания
-	if strings.HasPrefix(line, "@created:") {
-		dateStr := strings.TrimSpace(strings.TrimPrefix(line, "@created:"))
-		if date, err := time.Parse("2006-01-02", dateStr); err == nil {
-			testInfo.Created = date
-		}
-		return
+	if inFence && annotation == nil {
+		addBlock(fence)
 	}
+	flush()
 
-	// @updated: дата обновления
-	if strings.HasPrefix(line, "@updated:") {
-		dateStr := strings.TrimSpace(strings.TrimPrefix(line, "@updated:"))
-		if date, err := time.Parse("2006-01-02", dateStr); err == nil {
-			testInfo.Updated = date
-		}
-		return
-	}
+	testInfo.Description = strings.TrimSpace(description)
+}
 
-	// Произвольные метаданные @key: value
-	if strings.Contains(line, ":") {
-		parts := strings.SplitN(line, ":", 2)
-		key := strings.TrimSpace(strings.TrimPrefix(parts[0], "@"))
-		value := strings.TrimSpace(parts[1])
-		testInfo.Metadata[key] = value
-	}
+// splitList разбивает значение аннотации на элементы по запятым и пробелам
+func splitList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
 }
 
-// analyzeTestBody анализирует тело тест-функции
-func (p *Parser) analyzeTestBody(body *ast.BlockStmt, testInfo *types.TestInfo) {
-	ast.Inspect(body, func(n ast.Node) bool {
-		// Ищем вызовы t.Skip(), t.Skipf(), t.SkipNow()
-		if call, ok := n.(*ast.CallExpr); ok {
-			if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-				if sel.Sel.Name == "Skip" || sel.Sel.Name == "Skipf" || sel.Sel.Name == "SkipNow" {
-					testInfo.Skipped = true
-
-					// Пытаемся извлечь причину пропуска из аргументов
-					if len(call.Args) > 0 {
-						if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
-							reason, _ := strconv.Unquote(lit.Value)
-							testInfo.SkipReason = reason
-						}
-					}
-				}
+// appendUnique добавляет значения в список, пропуская уже присутствующие
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range list {
+			if existing == value {
+				found = true
+				break
 			}
 		}
-		return true
-	})
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
 }
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
@@ -235,6 +236,7 @@ func TestParser_parseAnnotation(t *testing.T) {
 			line: "@type: integration",
 			check: func(t *testing.T, ti *types.TestInfo) {
 				assert.Equal(t, types.IntegrationTest, ti.Type)
+				assert.True(t, ti.ExplicitType)
 			},
 		},
 		{
@@ -262,11 +264,75 @@ func TestParser_parseAnnotation(t *testing.T) {
 				assert.Equal(t, "Test description", ti.TestCases[0].Description)
 			},
 		},
+		{
+			name: "owner_annotation",
+			line: "@owner: @org/payments, @alice",
+			check: func(t *testing.T, ti *types.TestInfo) {
+				assert.Equal(t, []string{"@org/payments", "@alice"}, ti.Owners)
+			},
+		},
+		{
+			name: "requirement_annotation",
+			line: "@requirement: REQ-1, REQ-2 REQ-1",
+			check: func(t *testing.T, ti *types.TestInfo) {
+				assert.Equal(t, []string{"REQ-1", "REQ-2"}, ti.Requirements)
+				assert.Empty(t, ti.Metadata)
+			},
+		},
+		{
+			name: "issue_annotation",
+			line: "@issue: BUG-42",
+			check: func(t *testing.T, ti *types.TestInfo) {
+				assert.Equal(t, []string{"BUG-42"}, ti.Issues)
+			},
+		},
+		{
+			name: "story_annotation",
+			line: "@story: STORY-7,STORY-8",
+			check: func(t *testing.T, ti *types.TestInfo) {
+				assert.Equal(t, []string{"STORY-7", "STORY-8"}, ti.Stories)
+			},
+		},
+		{
+			name: "requires_annotation",
+			line: "@requires: env:DATABASE_URL, redis, docker",
+			check: func(t *testing.T, ti *types.TestInfo) {
+				assert.Equal(t, []types.Dependency{
+					{Kind: types.DependencyEnv, Name: "DATABASE_URL"},
+					{Kind: types.DependencyCustom, Name: "redis"},
+					{Kind: types.DependencyDocker},
+				}, ti.Dependencies)
+				assert.Empty(t, ti.Metadata)
+			},
+		},
 		{
 			name: "custom_metadata",
+			line: "@component: billing",
+			check: func(t *testing.T, ti *types.TestInfo) {
+				assert.Equal(t, "billing", ti.Metadata["component"])
+			},
+		},
+		{
+			name: "priority",
 			line: "@priority: high",
 			check: func(t *testing.T, ti *types.TestInfo) {
-				assert.Equal(t, "high", ti.Metadata["priority"])
+				assert.Equal(t, types.P1, ti.Priority)
+				assert.Empty(t, ti.Metadata)
+			},
+		},
+		{
+			name: "priority_unknown",
+			line: "@priority: urgent",
+			check: func(t *testing.T, ti *types.TestInfo) {
+				assert.Empty(t, ti.Priority)
+				assert.Equal(t, "urgent", ti.Metadata["priority"])
+			},
+		},
+		{
+			name: "severity",
+			line: "@severity: Critical",
+			check: func(t *testing.T, ti *types.TestInfo) {
+				assert.Equal(t, types.SeverityCritical, ti.Severity)
 			},
 		},
 		{
@@ -351,6 +417,159 @@ func RegularFunction() {
 	assert.Equal(t, 1, result.Stats.PackageCount)
 }
 
+func TestParser_ParseDirectory_File(t *testing.T) {
+	tmpDir := t.TempDir()
+	testCode := `package testpkg
+
+import "testing"
+
+func TestOne(t *testing.T) {}
+`
+	testFile := filepath.Join(tmpDir, "one_test.go")
+	require.NoError(t, os.WriteFile(testFile, []byte(testCode), 0644))
+	// Соседний файл не должен попасть в результат
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "two_test.go"),
+		[]byte("package testpkg\n\nimport \"testing\"\n\nfunc TestTwo(t *testing.T) {}\n"), 0644))
+
+	result, err := New().ParseDirectory(testFile, types.DefaultConfig())
+	require.NoError(t, err)
+
+	require.Contains(t, result.Packages, "testpkg")
+	tests := result.Packages["testpkg"].Tests
+	require.Len(t, tests, 1)
+	assert.Equal(t, "TestOne", tests[0].Name)
+	assert.Equal(t, tmpDir, result.Packages["testpkg"].Path)
+}
+
+func TestParser_ParseDirectory_CodeOwners(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "parser_codeowners_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, ".git"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "payments"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "CODEOWNERS"),
+		[]byte("*  @org/core\n/payments/ @org/payments\n"), 0644))
+
+	testCode := `package payments
+
+import "testing"
+
+func TestCharge(t *testing.T) {}
+
+// @owner: @org/billing
+func TestInvoice(t *testing.T) {}
+
+func TestRefund(t *testing.T) {
+	t.Skip("flaky")
+}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "payments", "payment_test.go"), []byte(testCode), 0644))
+
+	parser := New()
+	result, err := parser.ParseDirectory(filepath.Join(tmpDir, "payments"), types.DefaultConfig())
+	require.NoError(t, err)
+
+	tests := result.Packages["payments"].Tests
+	assert.Equal(t, []string{"@org/payments"}, findTestByName(tests, "TestCharge").Owners)
+	assert.Equal(t, []string{"@org/billing"}, findTestByName(tests, "TestInvoice").Owners)
+
+	assert.Equal(t, types.OwnerStats{TotalTests: 2, SkippedTests: 1}, result.Stats.Owners["@org/payments"])
+	assert.Equal(t, types.OwnerStats{TotalTests: 1}, result.Stats.Owners["@org/billing"])
+}
+
+func TestParser_ParseFile_Gherkin(t *testing.T) {
+	testCode := `package checkout
+
+import "testing"
+
+// @given: a cart with one item
+// @then: the total is shown
+// @scenario: Paying by card - happy path
+// @given: a registered user
+// @and: a cart with two items
+// @when: the user pays by card
+// @then: the order is confirmed
+// @but: no email is sent in test mode
+func TestCheckout(t *testing.T) {}
+`
+
+	tmpDir, err := os.MkdirTemp("", "parser_gherkin_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "checkout_test.go")
+	require.NoError(t, os.WriteFile(testFile, []byte(testCode), 0644))
+
+	tests, err := New().ParseFile(testFile)
+	require.NoError(t, err)
+	require.Len(t, tests, 1)
+
+	cases := tests[0].TestCases
+	require.Len(t, cases, 2)
+
+	// Шаги до @scenario попадают в сценарий с именем теста
+	assert.Equal(t, "TestCheckout", cases[0].Name)
+	assert.Equal(t, []types.ScenarioStep{
+		{Keyword: types.Given, Text: "a cart with one item"},
+		{Keyword: types.Then, Text: "the total is shown"},
+	}, cases[0].Scenario)
+
+	assert.Equal(t, "Paying by card", cases[1].Name)
+	assert.Equal(t, "happy path", cases[1].Description)
+	assert.Equal(t, []types.ScenarioStep{
+		{Keyword: types.Given, Text: "a registered user"},
+		{Keyword: types.And, Text: "a cart with two items"},
+		{Keyword: types.When, Text: "the user pays by card"},
+		{Keyword: types.Then, Text: "the order is confirmed"},
+		{Keyword: types.But, Text: "no email is sent in test mode"},
+	}, cases[1].Scenario)
+	assert.Empty(t, tests[0].Metadata)
+}
+
+func TestParser_ParseFile_InputExpected(t *testing.T) {
+	testCode := "package users\n\nimport \"testing\"\n\n" +
+		"// @testcase: Create user - creates a user from a JSON payload\n" +
+		"// @input: {\n" +
+		"//     \"name\": \"alice\",\n" +
+		"//     \"age\": 30\n" +
+		"//   }\n" +
+		"// @expected: 201 Created\n" +
+		"// @step: Send request - returns 201\n" +
+		"//   The request is sent with the admin token.\n" +
+		"// @testcase: Invalid payload\n" +
+		"// @input:\n" +
+		"// ```yaml\n" +
+		"// name: \"\"\n" +
+		"// ```\n" +
+		"func TestCreateUser(t *testing.T) {}\n"
+
+	tmpDir, err := os.MkdirTemp("", "parser_input_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	testFile := filepath.Join(tmpDir, "users_test.go")
+	require.NoError(t, os.WriteFile(testFile, []byte(testCode), 0644))
+
+	tests, err := New().ParseFile(testFile)
+	require.NoError(t, err)
+	require.Len(t, tests, 1)
+
+	cases := tests[0].TestCases
+	require.Len(t, cases, 2)
+
+	assert.Equal(t, "{\n  \"name\": \"alice\",\n  \"age\": 30\n}", cases[0].Input)
+	assert.Equal(t, "201 Created", cases[0].Expected)
+	require.Len(t, cases[0].Steps, 1)
+	assert.Equal(t, "Send request", cases[0].Steps[0].Action)
+	assert.Equal(t, "returns 201", cases[0].Steps[0].Expected)
+	assert.Equal(t, "The request is sent with the admin token.", cases[0].Steps[0].Description)
+
+	assert.Equal(t, "name: \"\"", cases[1].Input)
+	assert.Empty(t, cases[1].Expected)
+	assert.Empty(t, tests[0].Metadata)
+}
+
 // Вспомогательная функция для поиска теста по имени
 func findTestByName(tests []types.TestInfo, name string) *types.TestInfo {
 	for i := range tests {
@@ -360,3 +579,60 @@ func findTestByName(tests []types.TestInfo, name string) *types.TestInfo {
 	}
 	return nil
 }
+
+func TestParser_ParseSource(t *testing.T) {
+	src := []byte(`package buffer
+
+import "testing"
+
+// TestEdited проверяет несохраненные изменения
+// @type: functional
+func TestEdited(t *testing.T) {}
+`)
+
+	tests, err := New().ParseSource("internal/buffer/edited_test.go", src)
+	require.NoError(t, err)
+	require.Len(t, tests, 1)
+	assert.Equal(t, "TestEdited", tests[0].Name)
+	assert.Equal(t, types.FunctionalTest, tests[0].Type)
+	assert.Equal(t, "edited_test.go", tests[0].File)
+	assert.Equal(t, 7, tests[0].Line)
+
+	_, err = New().ParseSource("empty_test.go", nil)
+	assert.Error(t, err)
+}
+
+func TestParser_ParseFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"CODEOWNERS": {Data: []byte("/api/ @org/api\n")},
+		"api/handler_test.go": {Data: []byte(`package api
+
+import "testing"
+
+func TestHandler(t *testing.T) {}
+`)},
+		"api/testdata/fixture_test.go": {Data: []byte("not go")},
+		"api/handler.go":               {Data: []byte("package api\n")},
+		"web/page_test.go": {Data: []byte(`package web
+
+import "testing"
+
+func TestPage(t *testing.T) {}
+`)},
+	}
+
+	result, err := New().ParseFS(fsys, ".", types.DefaultConfig())
+	require.NoError(t, err)
+	require.Len(t, result.Packages, 2)
+	assert.Equal(t, "api", result.Packages["api"].Path)
+	assert.Equal(t, []string{"@org/api"}, result.Packages["api"].Tests[0].Owners)
+	assert.Empty(t, result.Packages["web"].Tests[0].Owners)
+
+	result, err = New().ParseFS(fsys, "web", types.DefaultConfig())
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Stats.TotalTests)
+	assert.NotNil(t, result.Packages["web"])
+
+	_, err = New().ParseFS(fsys, "missing", types.DefaultConfig())
+	assert.Error(t, err)
+}
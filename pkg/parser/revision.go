@@ -0,0 +1,19 @@
+package parser
+
+import (
+	"github.com/seblex/testdoc/pkg/gitfs"
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// ParseRevision анализирует директорию dir в состоянии на git ревизию rev
+// (ветку, тег или коммит). Файлы читаются из дерева ревизии, рабочая копия
+// не меняется. Пути пакетов в результате задаются относительно корня репозитория.
+func (p *Parser) ParseRevision(dir, rev string, config *types.Config) (*types.ParseResult, error) {
+	fsys, err := gitfs.Open(dir, rev)
+	if err != nil {
+		return nil, err
+	}
+	defer fsys.Close()
+
+	return p.ParseFS(fsys, fsys.Dir(), config)
+}
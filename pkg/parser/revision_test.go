@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func TestParser_ParseRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git не установлен")
+	}
+
+	repo := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	dir := filepath.Join(repo, "payments")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "CODEOWNERS"), []byte("/payments/ @org/payments\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "payment_test.go"), []byte(`package payments
+
+import "testing"
+
+// @type: integration
+func TestCharge(t *testing.T) {}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "other_test.go"), []byte("package other\n\nfunc TestOther(t *testing.T) {}\n"), 0644))
+	run("init", "-q")
+	run("add", "-A")
+	run("commit", "-q", "-m", "initial")
+
+	// Незакоммиченный тест не попадает в инвентаризацию ревизии
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "refund_test.go"), []byte(`package payments
+
+import "testing"
+
+func TestRefund(t *testing.T) {}
+`), 0644))
+
+	result, err := New().ParseRevision(dir, "HEAD", types.DefaultConfig())
+	require.NoError(t, err)
+
+	require.Len(t, result.Packages, 1)
+	pkg := result.Packages["payments"]
+	require.NotNil(t, pkg)
+	assert.Equal(t, "payments", pkg.Path)
+	require.Len(t, pkg.Tests, 1)
+	assert.Equal(t, "TestCharge", pkg.Tests[0].Name)
+	assert.Equal(t, types.IntegrationTest, pkg.Tests[0].Type)
+	assert.Equal(t, []string{"@org/payments"}, pkg.Tests[0].Owners)
+
+	_, err = New().ParseRevision(dir, "no-such-revision", types.DefaultConfig())
+	assert.Error(t, err)
+}
@@ -0,0 +1,496 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	gotypes "go/types"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// detectSkips находит вызовы t.Skip(), t.Skipf(), t.SkipNow() и ограничения //go:build.
+// Тест считается пропущенным только при безусловном вызове Skip.
+func detectSkips(src *Source, testInfo *types.TestInfo) {
+	if constraint := buildConstraint(src.File); constraint != "" {
+		testInfo.Skips = append(testInfo.Skips, types.SkipInfo{
+			Kind:      types.SkipBuildTag,
+			Condition: constraint,
+		})
+	}
+
+	newSkipAnalyzer(src).analyze(src.Func.Body, testInfo)
+}
+
+// skipAnalyzer ищет вызовы t.Skip/Skipf/SkipNow и определяет условия, при которых они выполняются
+type skipAnalyzer struct {
+	fileSet *token.FileSet
+	consts  constScope
+	assigns map[string]ast.Expr
+}
+
+// newSkipAnalyzer собирает константы и локальные присваивания для разрешения условий и причин пропуска
+func newSkipAnalyzer(src *Source) *skipAnalyzer {
+	a := &skipAnalyzer{
+		fileSet: src.FileSet,
+		consts:  src.consts,
+		assigns: make(map[string]ast.Expr),
+	}
+
+	ast.Inspect(src.Func.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.GenDecl:
+			if node.Tok == token.VAR {
+				for _, spec := range node.Specs {
+					if vs, ok := spec.(*ast.ValueSpec); ok && len(vs.Names) == len(vs.Values) {
+						for i, name := range vs.Names {
+							a.assigns[name.Name] = vs.Values[i]
+						}
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			// x := os.Getenv("X") и dsn, ok := os.LookupEnv("DSN")
+			for i, lhs := range node.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if len(node.Rhs) == len(node.Lhs) {
+					a.assigns[ident.Name] = node.Rhs[i]
+				} else if len(node.Rhs) == 1 {
+					a.assigns[ident.Name] = node.Rhs[0]
+				}
+			}
+		}
+		return true
+	})
+
+	return a
+}
+
+// analyze заполняет testInfo.Skips, Skipped и SkipReason
+func (a *skipAnalyzer) analyze(body *ast.BlockStmt, testInfo *types.TestInfo) {
+	var stack []ast.Node
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		var reason string
+		switch sel.Sel.Name {
+		case "Skip":
+			reason = a.formatArgs(call.Args)
+		case "Skipf":
+			if len(call.Args) > 0 {
+				reason = a.formatSkipf(call.Args[0], call.Args[1:])
+			}
+		case "SkipNow":
+		default:
+			return true
+		}
+
+		skip := a.classify(stack)
+		skip.Reason = reason
+		skip.Line = a.fileSet.Position(call.Pos()).Line
+		testInfo.Skips = append(testInfo.Skips, skip)
+
+		if !skip.Conditional() && !testInfo.Skipped {
+			testInfo.Skipped = true
+			testInfo.SkipReason = reason
+		}
+		return true
+	})
+}
+
+// classify определяет условие пропуска по цепочке родительских узлов вызова.
+// Условными считаются ветки if, switch, type switch и select, тела циклов
+// for/range, подтесты t.Run и замыкания, которые вызываются не сразу.
+func (a *skipAnalyzer) classify(stack []ast.Node) types.SkipInfo {
+	var conditions []string
+	var guards []ast.Node
+
+	prepend := func(condition string) {
+		conditions = append([]string{condition}, conditions...)
+	}
+
+loop:
+	for i := len(stack) - 2; i >= 0; i-- {
+		child := stack[i+1]
+
+		switch node := stack[i].(type) {
+		case *ast.FuncLit:
+			if a.isSubtestBody(stack[:i], node) {
+				return types.SkipInfo{
+					Kind:      types.SkipSubtest,
+					Condition: a.subtestName(stack[:i], node),
+				}
+			}
+			// Сразу вызываемое замыкание, в том числе defer func() {...}(),
+			// выполняется вместе с окружающим кодом
+			if i > 0 {
+				if call, ok := stack[i-1].(*ast.CallExpr); ok && call.Fun == node {
+					continue
+				}
+			}
+			prepend(closureCondition(stack[:i], node))
+			break loop
+		case *ast.IfStmt:
+			if node.Init != nil {
+				guards = append(guards, node.Init)
+			}
+			guards = append(guards, node.Cond)
+			switch child {
+			case node.Body:
+				prepend(exprString(node.Cond))
+			case node.Else:
+				prepend("!(" + exprString(node.Cond) + ")")
+			}
+		case *ast.CaseClause:
+			if i < 2 {
+				continue
+			}
+			for _, expr := range node.List {
+				guards = append(guards, expr)
+			}
+			switch switchStmt := stack[i-2].(type) {
+			case *ast.SwitchStmt:
+				if switchStmt.Tag != nil {
+					guards = append(guards, switchStmt.Tag)
+				}
+				tag := ""
+				if switchStmt.Tag != nil {
+					tag = exprString(switchStmt.Tag)
+				}
+				prepend(caseCondition(tag, node))
+			case *ast.TypeSwitchStmt:
+				tag := typeSwitchTag(switchStmt)
+				if tag != nil {
+					guards = append(guards, tag)
+					prepend(caseCondition(exprString(tag)+".(type)", node))
+				} else {
+					prepend(caseCondition("", node))
+				}
+			}
+		case *ast.CommClause:
+			if node.Comm != nil {
+				guards = append(guards, node.Comm)
+			}
+			prepend(a.commCondition(node))
+		case *ast.ForStmt:
+			if child != node.Body {
+				continue
+			}
+			if node.Cond == nil {
+				prepend("for")
+				continue
+			}
+			guards = append(guards, node.Cond)
+			prepend(exprString(node.Cond))
+		case *ast.RangeStmt:
+			if child != node.Body {
+				continue
+			}
+			guards = append(guards, node.X)
+			prepend("range " + exprString(node.X))
+		}
+	}
+
+	if len(conditions) == 0 {
+		return types.SkipInfo{Kind: types.SkipAlways}
+	}
+
+	skip := types.SkipInfo{
+		Kind:      types.SkipCondition,
+		Condition: strings.Join(conditions, " && "),
+	}
+	for _, guard := range guards {
+		if kind, detail := a.guardKind(guard, 0); kind != types.SkipCondition {
+			skip.Kind, skip.Detail = kind, detail
+			break
+		}
+	}
+	return skip
+}
+
+// guardKind распознает testing.Short(), os.Getenv/LookupEnv, runtime.GOOS и runtime.GOARCH
+// в выражении условия, в том числе через локальные переменные
+func (a *skipAnalyzer) guardKind(node ast.Node, depth int) (types.SkipKind, string) {
+	kind, detail := types.SkipCondition, ""
+	if node == nil || depth > maxConstDepth {
+		return kind, detail
+	}
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		if kind != types.SkipCondition {
+			return false
+		}
+
+		switch expr := n.(type) {
+		case *ast.CallExpr:
+			if pkg, name := selectorName(expr.Fun); pkg == "testing" && name == "Short" {
+				kind = types.SkipShort
+			} else if pkg == "os" && (name == "Getenv" || name == "LookupEnv") {
+				kind = types.SkipEnv
+				if len(expr.Args) > 0 {
+					if value, ok := a.consts.value(expr.Args[0], 0); ok {
+						detail = fmt.Sprint(value)
+					}
+				}
+			}
+		case *ast.SelectorExpr:
+			if pkg, name := selectorName(expr); pkg == "runtime" && name == "GOOS" {
+				kind = types.SkipOS
+			} else if pkg == "runtime" && name == "GOARCH" {
+				kind = types.SkipArch
+			}
+		case *ast.Ident:
+			if assigned, ok := a.assigns[expr.Name]; ok {
+				kind, detail = a.guardKind(assigned, depth+1)
+			}
+		}
+		return true
+	})
+
+	return kind, detail
+}
+
+// isSubtestBody проверяет, что замыкание передано телом подтеста в t.Run("name", func...)
+func (a *skipAnalyzer) isSubtestBody(parents []ast.Node, lit *ast.FuncLit) bool {
+	if len(parents) == 0 {
+		return false
+	}
+	call, ok := parents[len(parents)-1].(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	_, name := selectorName(call.Fun)
+	return name == "Run" && len(call.Args) >= 2 && call.Args[1] == lit
+}
+
+// subtestName возвращает условие для пропуска внутри t.Run("name", func...)
+func (a *skipAnalyzer) subtestName(parents []ast.Node, lit *ast.FuncLit) string {
+	if !a.isSubtestBody(parents, lit) {
+		return ""
+	}
+	call := parents[len(parents)-1].(*ast.CallExpr)
+	if value, ok := a.consts.value(call.Args[0], 0); ok {
+		return fmt.Sprintf("t.Run(%q)", fmt.Sprint(value))
+	}
+	return fmt.Sprintf("t.Run(%s)", exprString(call.Args[0]))
+}
+
+// formatArgs формирует причину t.Skip(args...) по правилам fmt.Sprintln
+func (a *skipAnalyzer) formatArgs(args []ast.Expr) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = a.argString(arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatSkipf формирует причину t.Skipf(format, args...). Если все аргументы
+// константные, причина совпадает с результатом fmt.Sprintf. Иначе неконстантные
+// аргументы выводятся как {выражение}; при спецификаторах с * или [n], где
+// аргументы не сопоставить глаголам по порядку, выводятся формат и все аргументы.
+func (a *skipAnalyzer) formatSkipf(formatExpr ast.Expr, args []ast.Expr) string {
+	value, ok := a.consts.value(formatExpr, 0)
+	format, isString := value.(string)
+	if !ok || !isString {
+		return a.formatArgs(append([]ast.Expr{formatExpr}, args...))
+	}
+
+	values := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		argValue, ok := a.consts.value(arg, 0)
+		if !ok {
+			break
+		}
+		// Нетипизированная целая константа передается в Skipf как int
+		if n, isInt := argValue.(int64); isInt {
+			argValue = int(n)
+		}
+		values = append(values, argValue)
+	}
+	if len(values) == len(args) {
+		return fmt.Sprintf(format, values...)
+	}
+
+	var sb strings.Builder
+	argIndex := 0
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			sb.WriteByte(format[i])
+			continue
+		}
+
+		// Спецификатор: флаги, ширина, точность и глагол
+		j := i + 1
+		for j < len(format) && strings.IndexByte("+-# 0123456789.[]*", format[j]) >= 0 {
+			j++
+		}
+		if j >= len(format) {
+			sb.WriteString(format[i:])
+			break
+		}
+
+		spec := format[i : j+1]
+		i = j
+
+		if spec == "%%" {
+			sb.WriteByte('%')
+			continue
+		}
+		if strings.ContainsAny(spec, "*[") {
+			return a.formatArgs(append([]ast.Expr{formatExpr}, args...))
+		}
+		if argIndex >= len(args) {
+			sb.WriteString(spec)
+			continue
+		}
+
+		arg := args[argIndex]
+		argIndex++
+		if argValue, ok := a.consts.value(arg, 0); ok {
+			sb.WriteString(fmt.Sprintf(spec, argValue))
+		} else {
+			sb.WriteString("{" + exprString(arg) + "}")
+		}
+	}
+
+	return sb.String()
+}
+
+// argString возвращает значение константного аргумента или {выражение}
+func (a *skipAnalyzer) argString(arg ast.Expr) string {
+	if value, ok := a.consts.value(arg, 0); ok {
+		return fmt.Sprint(value)
+	}
+	return "{" + exprString(arg) + "}"
+}
+
+// caseCondition формирует условие для ветки switch; tag - текст выражения
+// переключателя или пустая строка для switch без выражения
+func caseCondition(tag string, clause *ast.CaseClause) string {
+	if len(clause.List) == 0 {
+		if tag == "" {
+			return "default"
+		}
+		return tag + " (default)"
+	}
+
+	values := make([]string, len(clause.List))
+	for i, expr := range clause.List {
+		values[i] = exprString(expr)
+	}
+
+	if tag == "" {
+		return strings.Join(values, " || ")
+	}
+	if len(values) == 1 {
+		return tag + " == " + values[0]
+	}
+	return tag + " in (" + strings.Join(values, ", ") + ")"
+}
+
+// typeSwitchTag возвращает выражение x из x.(type) переключателя типов
+func typeSwitchTag(stmt *ast.TypeSwitchStmt) ast.Expr {
+	var expr ast.Expr
+	switch assign := stmt.Assign.(type) {
+	case *ast.ExprStmt:
+		expr = assign.X
+	case *ast.AssignStmt:
+		if len(assign.Rhs) == 1 {
+			expr = assign.Rhs[0]
+		}
+	}
+	if assertion, ok := expr.(*ast.TypeAssertExpr); ok {
+		return assertion.X
+	}
+	return nil
+}
+
+// commCondition формирует условие для ветки select
+func (a *skipAnalyzer) commCondition(clause *ast.CommClause) string {
+	if clause.Comm == nil {
+		return "select default"
+	}
+	var sb strings.Builder
+	if err := printer.Fprint(&sb, a.fileSet, clause.Comm); err != nil {
+		return "select"
+	}
+	return "select " + sb.String()
+}
+
+// closureCondition формирует условие для пропуска внутри замыкания, которое
+// выполняется только при вызове: имя переменной или функции, получающей замыкание
+func closureCondition(parents []ast.Node, lit *ast.FuncLit) string {
+	if len(parents) > 0 {
+		switch parent := parents[len(parents)-1].(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range parent.Rhs {
+				if rhs == lit && i < len(parent.Lhs) {
+					return exprString(parent.Lhs[i]) + "()"
+				}
+			}
+		case *ast.CallExpr:
+			return exprString(parent.Fun) + "(func)"
+		}
+	}
+	return "func()"
+}
+
+// buildConstraint возвращает выражение //go:build (или // +build) файла
+func buildConstraint(file *ast.File) string {
+	if file == nil {
+		return ""
+	}
+
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if expr, ok := strings.CutPrefix(comment.Text, "//go:build "); ok {
+				return strings.TrimSpace(expr)
+			}
+			if expr, ok := strings.CutPrefix(comment.Text, "// +build "); ok {
+				return strings.TrimSpace(expr)
+			}
+		}
+	}
+
+	return ""
+}
+
+// selectorName возвращает имя пакета и идентификатор для выражения вида pkg.Name
+func selectorName(expr ast.Expr) (string, string) {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return "", ""
+	}
+	if ident, ok := sel.X.(*ast.Ident); ok {
+		return ident.Name, sel.Sel.Name
+	}
+	return "", sel.Sel.Name
+}
+
+// exprString возвращает исходный текст выражения
+func exprString(expr ast.Expr) string {
+	return gotypes.ExprString(expr)
+}
@@ -0,0 +1,246 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func parseTestSource(t *testing.T, testCode string) map[string]types.TestInfo {
+	t.Helper()
+
+	testFile := filepath.Join(t.TempDir(), "skip_test.go")
+	require.NoError(t, os.WriteFile(testFile, []byte(testCode), 0644))
+
+	tests, err := New().ParseFile(testFile)
+	require.NoError(t, err)
+
+	byName := make(map[string]types.TestInfo)
+	for _, test := range tests {
+		byName[test.Name] = test
+	}
+	return byName
+}
+
+func TestDetectSkips(t *testing.T) {
+	testCode := `//go:build integration
+
+package example
+
+import (
+	"os"
+	"runtime"
+	"testing"
+)
+
+const reasonDB = "database " + "not available"
+
+func TestAlways(t *testing.T) {
+	t.Skip(reasonDB)
+}
+
+func TestShort(t *testing.T) {
+	if testing.Short() {
+		t.Skip("slow test")
+	}
+}
+
+func TestEnv(t *testing.T) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		t.Skipf("%s is not set", "DATABASE_URL")
+	}
+}
+
+func TestLookupEnv(t *testing.T) {
+	if _, ok := os.LookupEnv("CI"); !ok {
+		t.SkipNow()
+	}
+}
+
+func TestOS(t *testing.T) {
+	switch runtime.GOOS {
+	case "windows":
+		t.Skip("not supported on windows")
+	}
+}
+
+func TestArch(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		return
+	} else {
+		t.Skip("amd64 only")
+	}
+}
+
+func TestSubtest(t *testing.T) {
+	t.Run("legacy", func(t *testing.T) {
+		t.Skip("deprecated")
+	})
+}
+
+func TestCustom(t *testing.T) {
+	n := 3
+	if n > 2 {
+		t.Skipf("too many: %d", n)
+	}
+}
+
+func TestTypeSwitch(t *testing.T) {
+	var value interface{} = 1
+	switch v := value.(type) {
+	case string:
+		t.Skip("strings", v)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	done := make(chan struct{})
+	select {
+	case <-done:
+		t.Skip("done")
+	default:
+	}
+}
+
+func TestFor(t *testing.T) {
+	for i := 0; i < len(os.Args); i++ {
+		t.Skip("has args")
+	}
+}
+
+func TestRange(t *testing.T) {
+	for _, arg := range os.Args {
+		t.Skip("arg", arg)
+	}
+}
+
+func TestRangeEnv(t *testing.T) {
+	for _, name := range os.Getenv("SKIP") {
+		t.Skip(name)
+	}
+}
+
+func TestHelperClosure(t *testing.T) {
+	skipIfSlow := func() {
+		t.Skip("slow")
+	}
+	_ = skipIfSlow
+}
+
+func TestCallbackClosure(t *testing.T) {
+	withRetry(func() {
+		t.Skip("flaky")
+	})
+}
+
+func withRetry(f func()) {}
+
+func TestDeferred(t *testing.T) {
+	defer func() {
+		t.Skip("cleanup")
+	}()
+}
+`
+
+	tests := parseTestSource(t, testCode)
+	buildTag := types.SkipInfo{Kind: types.SkipBuildTag, Condition: "integration"}
+
+	always := tests["TestAlways"]
+	assert.True(t, always.Skipped)
+	assert.Equal(t, "database not available", always.SkipReason)
+	require.Len(t, always.Skips, 2)
+	assert.Equal(t, buildTag, always.Skips[0])
+	assert.Equal(t, types.SkipAlways, always.Skips[1].Kind)
+	assert.Equal(t, 14, always.Skips[1].Line)
+
+	expected := map[string]types.SkipInfo{
+		"TestShort":           {Kind: types.SkipShort, Condition: "testing.Short()", Reason: "slow test"},
+		"TestEnv":             {Kind: types.SkipEnv, Condition: `dsn == ""`, Detail: "DATABASE_URL", Reason: "DATABASE_URL is not set"},
+		"TestLookupEnv":       {Kind: types.SkipEnv, Condition: "!ok", Detail: "CI"},
+		"TestOS":              {Kind: types.SkipOS, Condition: `runtime.GOOS == "windows"`, Reason: "not supported on windows"},
+		"TestArch":            {Kind: types.SkipArch, Condition: `!(runtime.GOARCH != "amd64")`, Reason: "amd64 only"},
+		"TestSubtest":         {Kind: types.SkipSubtest, Condition: `t.Run("legacy")`, Reason: "deprecated"},
+		"TestCustom":          {Kind: types.SkipCondition, Condition: "n > 2", Reason: "too many: {n}"},
+		"TestTypeSwitch":      {Kind: types.SkipCondition, Condition: "value.(type) == string", Reason: "strings {v}"},
+		"TestSelect":          {Kind: types.SkipCondition, Condition: "select <-done", Reason: "done"},
+		"TestFor":             {Kind: types.SkipCondition, Condition: "i < len(os.Args)", Reason: "has args"},
+		"TestRange":           {Kind: types.SkipCondition, Condition: "range os.Args", Reason: "arg {arg}"},
+		"TestRangeEnv":        {Kind: types.SkipEnv, Condition: `range os.Getenv("SKIP")`, Detail: "SKIP", Reason: "{name}"},
+		"TestHelperClosure":   {Kind: types.SkipCondition, Condition: "skipIfSlow()", Reason: "slow"},
+		"TestCallbackClosure": {Kind: types.SkipCondition, Condition: "withRetry(func)", Reason: "flaky"},
+	}
+
+	// Сразу вызываемое замыкание выполняется вместе с тестом
+	deferred := tests["TestDeferred"]
+	assert.True(t, deferred.Skipped)
+	require.Len(t, deferred.Skips, 2)
+	assert.Equal(t, types.SkipAlways, deferred.Skips[1].Kind)
+
+	for name, want := range expected {
+		t.Run(name, func(t *testing.T) {
+			test := tests[name]
+			assert.False(t, test.Skipped)
+			assert.Empty(t, test.SkipReason)
+			require.Len(t, test.Skips, 2)
+			assert.Equal(t, buildTag, test.Skips[0])
+
+			got := test.Skips[1]
+			got.Line = 0
+			assert.Equal(t, want, got)
+			assert.Len(t, test.ConditionalSkips(), 2)
+		})
+	}
+}
+
+func TestDetectSkips_NoSkips(t *testing.T) {
+	testCode := `package example
+
+import "testing"
+
+func TestPlain(t *testing.T) {
+	t.Log("ok")
+}
+`
+
+	test := parseTestSource(t, testCode)["TestPlain"]
+	assert.False(t, test.Skipped)
+	assert.Empty(t, test.Skips)
+}
+
+func TestSkipAnalyzer_formatSkipf(t *testing.T) {
+	a := &skipAnalyzer{consts: map[string]ast.Expr{"w": &ast.BasicLit{Kind: token.INT, Value: "5"}}}
+	three := &ast.BasicLit{Kind: token.INT, Value: "3"}
+
+	tests := []struct {
+		name     string
+		format   string
+		args     []ast.Expr
+		expected string
+	}{
+		{"no_args", `"plain"`, nil, "plain"},
+		{"const_arg", `"port %d busy"`, []ast.Expr{&ast.BasicLit{Kind: token.INT, Value: "8080"}}, "port 8080 busy"},
+		{"expr_arg", `"got %v"`, []ast.Expr{ast.NewIdent("err")}, "got {err}"},
+		{"percent", `"100%% sure"`, nil, "100% sure"},
+		{"missing_arg", `"%s and %s"`, []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: `"a"`}}, "a and %!s(MISSING)"},
+		{"missing_arg_expr", `"%v and %s"`, []ast.Expr{ast.NewIdent("err")}, "{err} and %s"},
+		// Константные аргументы форматируются как fmt.Sprintf, включая * и [n]
+		{"star_index_const", `"need %*d workers, %[1]d"`, []ast.Expr{ast.NewIdent("w"), three}, "need     3 workers, 5"},
+		{"star_index_expr", `"need %*d workers, %[1]d"`, []ast.Expr{ast.NewIdent("workers"), three},
+			"need %*d workers, %[1]d {workers} 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format := &ast.BasicLit{Kind: token.STRING, Value: tt.format}
+			assert.Equal(t, tt.expected, a.formatSkipf(format, tt.args))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"go/ast"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// Пути импорта, по которым определяются таймауты и ожидания
+const (
+	importContext = "context"
+	importTime    = "time"
+)
+
+// detectTraits определяет особенности выполнения теста: t.Parallel(), t.Cleanup,
+// defer, вызовы хелперов с t.Helper(), таймауты контекста и time.Sleep.
+// Хелперы ищутся среди функций того же файла.
+func detectTraits(src *Source, testInfo *types.TestInfo) {
+	helpers := fileHelpers(src.File)
+	traits := &testInfo.Traits
+
+	ast.Inspect(src.Func.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.DeferStmt:
+			traits.Defer = true
+		case *ast.CallExpr:
+			if ident, ok := node.Fun.(*ast.Ident); ok && helpers[ident.Name] {
+				traits.Helpers = appendUnique(traits.Helpers, ident.Name)
+				return true
+			}
+
+			importPath, name := src.Selector(node.Fun)
+			switch {
+			case importPath == "" && name == "Parallel" && len(node.Args) == 0:
+				traits.Parallel = true
+			case importPath == "" && name == "Cleanup" && len(node.Args) == 1:
+				traits.Cleanup = true
+			case importPath == "" && name == "Deadline" && len(node.Args) == 0:
+				traits.Timeouts = appendUnique(traits.Timeouts, exprString(node))
+			case importPath == importContext && (name == "WithTimeout" || name == "WithDeadline") && len(node.Args) == 2:
+				traits.Timeouts = appendUnique(traits.Timeouts, exprString(node.Args[1]))
+			case importPath == importTime && name == "Sleep" && len(node.Args) == 1:
+				traits.Sleeps = appendUnique(traits.Sleeps, exprString(node.Args[0]))
+			}
+		}
+		return true
+	})
+}
+
+// fileHelpers возвращает имена функций файла, которые вызывают t.Helper()
+func fileHelpers(file *ast.File) map[string]bool {
+	helpers := make(map[string]bool)
+	if file == nil {
+		return helpers
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || fn.Recv != nil {
+			continue
+		}
+
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if helpers[fn.Name.Name] {
+				return false
+			}
+			if call, ok := n.(*ast.CallExpr); ok && len(call.Args) == 0 {
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Helper" {
+					helpers[fn.Name.Name] = true
+				}
+			}
+			return true
+		})
+	}
+
+	return helpers
+}
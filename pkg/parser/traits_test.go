@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+func TestDetectTraits(t *testing.T) {
+	testCode := `package example
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func setupDB(t *testing.T) func() {
+	t.Helper()
+	return func() {}
+}
+
+func notHelper() {}
+
+func TestOrders(t *testing.T) {
+	t.Parallel()
+
+	teardown := setupDB(t)
+	defer teardown()
+	t.Cleanup(func() {})
+	notHelper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = ctx
+
+	if _, ok := t.Deadline(); ok {
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestPlain(t *testing.T) {}
+`
+
+	tests := parseTestSource(t, testCode)
+
+	assert.Equal(t, types.ExecutionTraits{
+		Parallel: true,
+		Cleanup:  true,
+		Defer:    true,
+		Helpers:  []string{"setupDB"},
+		Timeouts: []string{"5 * time.Second", "t.Deadline()"},
+		Sleeps:   []string{"100 * time.Millisecond"},
+	}, tests["TestOrders"].Traits)
+
+	assert.True(t, tests["TestPlain"].Traits.Empty())
+}
@@ -0,0 +1,189 @@
+package parser
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/config"
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// maxSymlinkDepth - максимальное число символических ссылок в пути обхода,
+// как ограничение ELOOP в Linux. Дополняет проверку пройденных директорий.
+const maxSymlinkDepth = 40
+
+// walker обходит дерево с учетом паттернов, файлов игнорирования,
+// переопределений конфигурации и символических ссылок
+type walker struct {
+	parser *Parser
+	fsys   fs.FS
+	// visit вызывается для каждого тест-файла: name - путь в fsys,
+	// rel - путь относительно корня обхода, config - конфигурация поддерева
+	visit func(name, rel string, config *types.Config) error
+	// resolve раскрывает символические ссылки в пути директории
+	resolve func(name string) (string, error)
+	// visited - раскрытые пути пройденных директорий для защиты от циклов ссылок
+	visited map[string]bool
+}
+
+// scope - конфигурация поддерева. Паттерны файла переопределения
+// задаются относительно его директории base.
+type scope struct {
+	config *types.Config
+	base   string
+}
+
+// relative возвращает путь rel относительно директории поддерева
+func (s scope) relative(rel string) string {
+	if s.base == "" {
+		return rel
+	}
+	return strings.TrimPrefix(rel, s.base+"/")
+}
+
+// walk обходит дерево t и вызывает visit для тест-файлов, не исключенных
+// паттернами и файлами .gitignore/.testdocignore. Файлы .testdoc.yaml
+// в поддиректориях переопределяют конфигурацию для своих поддеревьев.
+func (p *Parser) walk(t tree, cfg *types.Config, visit func(name, rel string, config *types.Config) error) error {
+	info, err := fs.Stat(t.fsys, t.root)
+	if err != nil {
+		return err
+	}
+
+	w := &walker{parser: p, fsys: t.fsys, visit: visit, resolve: t.resolve, visited: make(map[string]bool)}
+	root := scope{config: cfg}
+	if !info.IsDir() {
+		return w.file(t.root, path.Base(t.root), root)
+	}
+
+	if cfg.FollowSymlinks {
+		w.enter(t.root)
+	}
+	return w.dir(t.root, "", root, nil, 0)
+}
+
+// dir обходит директорию name. rules - правила игнорирования родительских
+// директорий, links - число символических ссылок на пути к директории.
+func (w *walker) dir(name, rel string, s scope, rules ignoreRules, links int) error {
+	entries, err := fs.ReadDir(w.fsys, name)
+	if err != nil {
+		return err
+	}
+
+	// Конфигурация корня обхода уже задана вызывающим кодом
+	if rel != "" {
+		if s, err = w.loadOverride(name, rel, s); err != nil {
+			return err
+		}
+	}
+	rules = w.loadIgnore(name, rel, s.config, rules)
+
+	for _, entry := range entries {
+		childName := path.Join(name, entry.Name())
+		childRel := path.Join(rel, entry.Name())
+		isDir, childLinks := entry.IsDir(), links
+
+		if entry.Type()&fs.ModeSymlink != 0 {
+			info, err := fs.Stat(w.fsys, childName)
+			if err != nil {
+				// Битая ссылка
+				continue
+			}
+			if info.IsDir() {
+				// Без раскрытия ссылок (tree.resolve) нельзя обнаружить циклы,
+				// поэтому по ссылкам на директории обход не спускается
+				if !s.config.FollowSymlinks || w.resolve == nil || links >= maxSymlinkDepth {
+					continue
+				}
+				isDir, childLinks = true, links+1
+			}
+		}
+
+		if rules.ignored(childRel, isDir) {
+			continue
+		}
+
+		if !isDir {
+			if err := w.file(childName, childRel, s); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if skipDirs[entry.Name()] || w.parser.shouldExcludeDir(s.relative(childRel), s.config) {
+			continue
+		}
+		if s.config.FollowSymlinks && !w.enter(childName) {
+			continue
+		}
+		if err := w.dir(childName, childRel, s, rules, childLinks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// file передает тест-файл в visit, если он не исключен паттернами
+func (w *walker) file(name, rel string, s scope) error {
+	if !w.parser.isTestFile(rel) || w.parser.shouldExcludeFile(s.relative(rel), s.config) {
+		return nil
+	}
+	return w.visit(name, rel, s.config)
+}
+
+// loadOverride применяет файл .testdoc.yaml директории name к конфигурации поддерева
+func (w *walker) loadOverride(name, rel string, s scope) (scope, error) {
+	data, err := fs.ReadFile(w.fsys, path.Join(name, config.FileName))
+	if err != nil {
+		return s, nil
+	}
+
+	cfg, err := config.Overlay(s.config, path.Join(rel, config.FileName), data)
+	if err != nil {
+		return s, err
+	}
+	return scope{config: cfg, base: rel}, nil
+}
+
+// loadIgnore добавляет к rules правила файлов игнорирования директории name
+func (w *walker) loadIgnore(name, rel string, cfg *types.Config, rules ignoreRules) ignoreRules {
+	files := []string{testdocignoreFile}
+	if cfg.Gitignore {
+		files = []string{gitignoreFile, testdocignoreFile}
+	}
+
+	for _, file := range files {
+		f, err := w.fsys.Open(path.Join(name, file))
+		if err != nil {
+			continue
+		}
+		parsed, err := parseIgnore(f, rel)
+		f.Close()
+		if err != nil {
+			continue
+		}
+		// Копируем, чтобы правила соседних директорий не смешивались
+		rules = append(append(ignoreRules{}, rules...), parsed...)
+	}
+
+	return rules
+}
+
+// enter отмечает директорию name пройденной по ее раскрытому пути.
+// Возвращает false, если директория уже пройдена - напрямую или по ссылке.
+func (w *walker) enter(name string) bool {
+	if w.resolve == nil {
+		return true
+	}
+	resolved, err := w.resolve(name)
+	if err != nil {
+		return true
+	}
+	if w.visited[resolved] {
+		return false
+	}
+	w.visited[resolved] = true
+	return true
+}
@@ -0,0 +1,195 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// walkedFiles возвращает пути тест-файлов, которые посетит обход
+func walkedFiles(t *testing.T, tr tree, config *types.Config) []string {
+	t.Helper()
+
+	var files []string
+	err := New().walk(tr, config, func(name, rel string, _ *types.Config) error {
+		files = append(files, rel)
+		return nil
+	})
+	require.NoError(t, err)
+	sort.Strings(files)
+	return files
+}
+
+func TestParser_walk(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitignore":                        {Data: []byte("/generated\n*_local_test.go\n")},
+		".testdocignore":                    {Data: []byte("experimental/\n")},
+		"api/api_test.go":                   {},
+		"api/api_local_test.go":             {},
+		"api/api.go":                        {},
+		"api/.gitignore":                    {Data: []byte("old_test.go\n")},
+		"api/old_test.go":                   {},
+		"web/old_test.go":                   {},
+		"generated/gen_test.go":             {},
+		"pkg/generated/gen_test.go":         {},
+		"pkg/experimental/exp_test.go":      {},
+		"internal/legacy/legacy_test.go":    {},
+		"internal/core/core_test.go":        {},
+		"vendor/github.com/x/x_test.go":     {},
+		"node_modules/x/x_test.go":          {},
+		".git/hooks/hook_test.go":           {},
+		"testdata/fixtures/fixture_test.go": {},
+	}
+	tr := tree{fsys: fsys, root: "."}
+
+	config := types.DefaultConfig()
+	config.ExcludePatterns = []string{"internal/legacy/**", "**/testdata/**"}
+	assert.Equal(t, []string{
+		"api/api_test.go",
+		"internal/core/core_test.go",
+		"pkg/generated/gen_test.go",
+		"web/old_test.go",
+	}, walkedFiles(t, tr, config))
+
+	// Без .gitignore остаются только правила .testdocignore
+	config.Gitignore = false
+	assert.Equal(t, []string{
+		"api/api_local_test.go",
+		"api/api_test.go",
+		"api/old_test.go",
+		"generated/gen_test.go",
+		"internal/core/core_test.go",
+		"pkg/generated/gen_test.go",
+		"web/old_test.go",
+	}, walkedFiles(t, tr, config))
+
+	// Паттерны задаются относительно корня обхода
+	config = types.DefaultConfig()
+	config.IncludePatterns = []string{"api/*_test.go"}
+	assert.Equal(t, []string{"api/api_test.go"}, walkedFiles(t, tree{fsys: fsys, root: "."}, config))
+	config.IncludePatterns = []string{"*_test.go"}
+	assert.Equal(t, []string{"core_test.go"}, walkedFiles(t, tree{fsys: fsys, root: "internal/core"}, config))
+}
+
+func TestParser_walk_Symlinks(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "pkg", "api"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "shared"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "pkg", "api", "api_test.go"), nil, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "shared", "shared_test.go"), nil, 0644))
+
+	// Цикл: pkg/api/loop -> pkg; ссылка на директорию вне дерева обхода
+	if err := os.Symlink(filepath.Join(root, "pkg"), filepath.Join(root, "pkg", "api", "loop")); err != nil {
+		t.Skipf("символические ссылки не поддерживаются: %v", err)
+	}
+	require.NoError(t, os.Symlink(filepath.Join(root, "shared"), filepath.Join(root, "pkg", "shared")))
+	require.NoError(t, os.Symlink(filepath.Join(root, "missing"), filepath.Join(root, "pkg", "broken")))
+
+	tr := tree{
+		fsys: os.DirFS(filepath.Join(root, "pkg")),
+		root: ".",
+		resolve: func(name string) (string, error) {
+			return filepath.EvalSymlinks(filepath.Join(root, "pkg", filepath.FromSlash(name)))
+		},
+	}
+	config := types.DefaultConfig()
+
+	assert.Equal(t, []string{"api/api_test.go"}, walkedFiles(t, tr, config))
+
+	config.FollowSymlinks = true
+	assert.Equal(t, []string{"api/api_test.go", "shared/shared_test.go"}, walkedFiles(t, tr, config))
+
+	// Без раскрытия ссылок обход не спускается по ссылкам на директории
+	tr.resolve = nil
+	assert.Equal(t, []string{"api/api_test.go"}, walkedFiles(t, tr, config))
+}
+
+func TestParser_ParseFS_SymlinkLoops(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "pkg"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "pkg", "pkg_test.go"),
+		[]byte("package pkg\n\nimport \"testing\"\n\nfunc TestPkg(t *testing.T) {}\n"), 0644))
+
+	// Две ссылки на родительскую директорию: без защиты обход ветвится 2^40 раз
+	if err := os.Symlink("..", filepath.Join(root, "pkg", "up1")); err != nil {
+		t.Skipf("символические ссылки не поддерживаются: %v", err)
+	}
+	require.NoError(t, os.Symlink("..", filepath.Join(root, "pkg", "up2")))
+
+	config := types.DefaultConfig()
+	config.FollowSymlinks = true
+
+	result, err := New().ParseFS(os.DirFS(root), ".", config)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Stats.TotalTests)
+
+	result, err = New().ParseDirectory(root, config)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Stats.TotalTests)
+
+	tr := tree{fsys: os.DirFS(root), root: "."}
+	assert.Equal(t, []string{"pkg/pkg_test.go"}, walkedFiles(t, tr, config))
+}
+
+func TestParser_ParseFS_Overrides(t *testing.T) {
+	testFile := func(pkg string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte("package " + pkg + `
+
+import "testing"
+
+func TestActive(t *testing.T) {}
+
+func TestSkipped(t *testing.T) {
+	t.Skip("позже")
+}
+`)}
+	}
+	fsys := fstest.MapFS{
+		"api/api_test.go":                testFile("api"),
+		"legacy/.testdoc.yaml":           {Data: []byte("include_skipped: false\nexclude_patterns: [\"old/**\"]\n")},
+		"legacy/legacy_test.go":          testFile("legacy"),
+		"legacy/old/old_test.go":         testFile("old"),
+		"legacy/current/current_test.go": testFile("current"),
+		// Переопределение корня не применяется: корень настраивает вызывающий код
+		".testdoc.yaml": {Data: []byte("include_skipped: false\n")},
+	}
+
+	result, err := New().ParseFS(fsys, ".", types.DefaultConfig())
+	require.NoError(t, err)
+
+	assert.Len(t, result.Packages["api"].Tests, 2)
+	assert.Len(t, result.Packages["legacy"].Tests, 1)
+	assert.Len(t, result.Packages["current"].Tests, 1)
+	assert.NotContains(t, result.Packages, "old")
+
+	fsys["legacy/.testdoc.yaml"] = &fstest.MapFile{Data: []byte("include_skipped: [\n")}
+	_, err = New().ParseFS(fsys, ".", types.DefaultConfig())
+	assert.ErrorContains(t, err, "legacy/.testdoc.yaml")
+}
+
+func TestParser_ParseDirectory_SymlinkBeforeTarget(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "b"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "b", "x_test.go"),
+		[]byte("package b\n\nimport \"testing\"\n\nfunc TestX(t *testing.T) {}\n"), 0644))
+
+	// Ссылка a идет по алфавиту раньше директории b, на которую указывает
+	if err := os.Symlink("b", filepath.Join(root, "a")); err != nil {
+		t.Skipf("символические ссылки не поддерживаются: %v", err)
+	}
+
+	config := types.DefaultConfig()
+	config.FollowSymlinks = true
+
+	result, err := New().ParseDirectory(root, config)
+	require.NoError(t, err)
+	require.Contains(t, result.Packages, "b")
+	assert.Len(t, result.Packages["b"].Tests, 1)
+}
@@ -0,0 +1,184 @@
+// Package results загружает результаты запуска тестов из вывода go test -json
+package results
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// Outcome определяет итог выполнения теста
+type Outcome string
+
+const (
+	Pass Outcome = "pass"
+	Fail Outcome = "fail"
+	Skip Outcome = "skip"
+)
+
+// event - событие test2json, формируемое go test -json
+type event struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"`
+}
+
+// TestResult содержит итог выполнения одного теста
+type TestResult struct {
+	Package string
+	Name    string
+	Outcome Outcome
+	Elapsed time.Duration
+}
+
+// Report содержит итоги тестов верхнего уровня. Подтесты (TestA/case)
+// учитываются в итоге родительского теста.
+type Report struct {
+	Tests   map[string]TestResult
+	Passed  int
+	Failed  int
+	Skipped int
+}
+
+// Parse читает поток go test -json. Строки, не являющиеся JSON (например,
+// вывод сборки), пропускаются. При повторных запусках (-count) учитывается последний.
+func Parse(r io.Reader) (*Report, error) {
+	report := &Report{Tests: make(map[string]TestResult)}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(text, "{") {
+			continue
+		}
+
+		var e event
+		if err := json.Unmarshal([]byte(text), &e); err != nil {
+			return nil, fmt.Errorf("строка %d: %w", line, err)
+		}
+		if e.Test == "" || strings.Contains(e.Test, "/") {
+			continue
+		}
+
+		switch outcome := Outcome(e.Action); outcome {
+		case Pass, Fail, Skip:
+			report.Tests[e.Package+"."+e.Test] = TestResult{
+				Package: e.Package,
+				Name:    e.Test,
+				Outcome: outcome,
+				Elapsed: time.Duration(e.Elapsed * float64(time.Second)),
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, result := range report.Tests {
+		switch result.Outcome {
+		case Pass:
+			report.Passed++
+		case Fail:
+			report.Failed++
+		case Skip:
+			report.Skipped++
+		}
+	}
+
+	return report, nil
+}
+
+// Load читает результаты из файла с выводом go test -json
+func Load(filename string) (*Report, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Total возвращает количество тестов в отчете
+func (r *Report) Total() int {
+	return len(r.Tests)
+}
+
+// PassRate возвращает долю прошедших тестов среди выполненных
+// (пропущенные не учитываются), в процентах
+func (r *Report) PassRate() float64 {
+	executed := r.Passed + r.Failed
+	if executed == 0 {
+		return 0
+	}
+	return float64(r.Passed) * 100 / float64(executed)
+}
+
+// Attach сопоставляет результаты с тестами result и записывает их в поле Run.
+// Тест сопоставляется по имени; если одноименные тесты есть в нескольких
+// пакетах, выбирается результат, путь импорта которого больше всего совпадает
+// с директорией пакета по последним сегментам. Возвращает количество тестов,
+// для которых найден результат.
+func (r *Report) Attach(result *types.ParseResult) int {
+	byName := make(map[string][]TestResult)
+	for _, test := range r.Tests {
+		byName[test.Name] = append(byName[test.Name], test)
+	}
+
+	attached := 0
+	for _, pkg := range result.Packages {
+		for i := range pkg.Tests {
+			test := &pkg.Tests[i]
+			run, ok := match(byName[test.Name], pkg.Path, test.Package)
+			if !ok {
+				continue
+			}
+			test.Run = &types.RunResult{Outcome: string(run.Outcome), Elapsed: run.Elapsed}
+			attached++
+		}
+	}
+	return attached
+}
+
+// match выбирает результат теста из директории dir и пакета name среди
+// одноименных результатов
+func match(candidates []TestResult, dir, name string) (TestResult, bool) {
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+
+	best, bestScore := TestResult{}, 0
+	for _, candidate := range candidates {
+		score := commonSuffix(strings.Split(candidate.Package, "/"), strings.Split(filepath.ToSlash(dir), "/"))
+		if score == 0 && path.Base(candidate.Package) == name {
+			score = 1
+		}
+		if score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	return best, bestScore > 0
+}
+
+// commonSuffix возвращает количество совпадающих последних сегментов путей
+func commonSuffix(a, b []string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[len(a)-1-n] == b[len(b)-1-n] && a[len(a)-1-n] != "" {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,103 @@
+package results
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+const goTestJSON = `{"Action":"start","Package":"example.com/auth"}
+{"Action":"run","Package":"example.com/auth","Test":"TestLogin"}
+{"Action":"output","Package":"example.com/auth","Test":"TestLogin","Output":"=== RUN   TestLogin\n"}
+{"Action":"run","Package":"example.com/auth","Test":"TestLogin/empty"}
+{"Action":"fail","Package":"example.com/auth","Test":"TestLogin/empty","Elapsed":0}
+{"Action":"pass","Package":"example.com/auth","Test":"TestLogin","Elapsed":0.25}
+{"Action":"fail","Package":"example.com/auth","Test":"TestLogout","Elapsed":0.01}
+{"Action":"skip","Package":"example.com/auth","Test":"TestSession","Elapsed":0}
+# example.com/broken
+{"Action":"pass","Package":"example.com/api","Test":"TestHandler","Elapsed":0.1}
+{"Action":"pass","Package":"example.com/api","Test":"TestRetry","Elapsed":0.1}
+{"Action":"pass","Package":"example.com/auth","Elapsed":0.3}
+`
+
+func TestParse(t *testing.T) {
+	report, err := Parse(strings.NewReader(goTestJSON))
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, report.Total())
+	assert.Equal(t, 3, report.Passed)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 1, report.Skipped)
+	assert.InDelta(t, 75.0, report.PassRate(), 0.001)
+
+	login := report.Tests["example.com/auth.TestLogin"]
+	assert.Equal(t, Pass, login.Outcome)
+	assert.Equal(t, 250*time.Millisecond, login.Elapsed)
+}
+
+func TestParse_Rerun(t *testing.T) {
+	input := `{"Action":"fail","Package":"p","Test":"TestFlaky"}
+{"Action":"pass","Package":"p","Test":"TestFlaky"}
+`
+	report, err := Parse(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Passed)
+	assert.Equal(t, 0, report.Failed)
+}
+
+func TestParse_InvalidJSON(t *testing.T) {
+	_, err := Parse(strings.NewReader("{\"Action\":\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "строка 1")
+}
+
+func TestReport_PassRate_Empty(t *testing.T) {
+	report, err := Parse(strings.NewReader(""))
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, report.PassRate())
+}
+
+func TestLoad(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, os.WriteFile(filename, []byte(goTestJSON), 0644))
+
+	report, err := Load(filename)
+	require.NoError(t, err)
+	assert.Equal(t, 5, report.Total())
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestReport_Attach(t *testing.T) {
+	report, err := Parse(strings.NewReader(goTestJSON +
+		`{"Action":"pass","Package":"example.com/v2/auth","Test":"TestLogin","Elapsed":0.5}` + "\n"))
+	require.NoError(t, err)
+
+	result := &types.ParseResult{Packages: map[string]*types.PackageInfo{
+		"auth": {Name: "auth", Path: "/src/project/v2/auth", Tests: []types.TestInfo{
+			{Name: "TestLogin", Package: "auth"},
+			{Name: "TestLogout", Package: "auth"},
+			{Name: "TestUnknown", Package: "auth"},
+		}},
+		"api": {Name: "api", Path: "internal/api", Tests: []types.TestInfo{
+			{Name: "TestHandler", Package: "api"},
+		}},
+	}}
+
+	assert.Equal(t, 3, report.Attach(result))
+
+	auth := result.Packages["auth"].Tests
+	// Из двух одноименных тестов выбран пакет с совпадающей директорией
+	assert.Equal(t, &types.RunResult{Outcome: "pass", Elapsed: 500 * time.Millisecond}, auth[0].Run)
+	assert.Equal(t, "fail", auth[1].Run.Outcome)
+	assert.Nil(t, auth[2].Run)
+	assert.Equal(t, "pass", result.Packages["api"].Tests[0].Run.Outcome)
+}
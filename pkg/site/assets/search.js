@@ -0,0 +1,54 @@
+// Поиск по тестам на стороне клиента. Индекс берется из assets/search-index.js,
+// что позволяет открывать сайт без веб-сервера; при его отсутствии загружается
+// search-index.json.
+(function () {
+  var input = document.getElementById("search");
+  var results = document.getElementById("search-results");
+  if (!input || !results) {
+    return;
+  }
+
+  var root = document.body.getAttribute("data-root") || "";
+  var limit = 20;
+
+  function load(callback) {
+    if (window.TESTDOC_SEARCH_INDEX) {
+      callback(window.TESTDOC_SEARCH_INDEX);
+      return;
+    }
+    fetch(root + "search-index.json")
+      .then(function (response) { return response.json(); })
+      .then(callback);
+  }
+
+  function matches(entry, terms) {
+    var text = [entry.title, entry.package, entry.type, entry.description || "", (entry.tags || []).join(" ")]
+      .join(" ")
+      .toLowerCase();
+    return terms.every(function (term) { return text.indexOf(term) !== -1; });
+  }
+
+  function render(found) {
+    results.innerHTML = "";
+    found.slice(0, limit).forEach(function (entry) {
+      var item = document.createElement("li");
+      var link = document.createElement("a");
+      link.href = root + entry.url;
+      link.textContent = entry.title;
+      var meta = document.createElement("span");
+      meta.className = "count";
+      meta.textContent = entry.package + " · " + entry.type;
+      item.appendChild(link);
+      item.appendChild(document.createTextNode(" "));
+      item.appendChild(meta);
+      results.appendChild(item);
+    });
+  }
+
+  load(function (index) {
+    input.addEventListener("input", function () {
+      var terms = input.value.toLowerCase().split(/\s+/).filter(Boolean);
+      render(terms.length ? index.filter(function (entry) { return matches(entry, terms); }) : []);
+    });
+  });
+})();
@@ -0,0 +1,168 @@
+package site
+
+import (
+	"embed"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+//go:embed templates/site.html
+var templatesFS embed.FS
+
+//go:embed assets/search.js assets/style.css
+var assetsFS embed.FS
+
+// pageTemplate - шаблон страниц HTML сайта
+var pageTemplate = template.Must(template.New("site").
+	Funcs(template.FuncMap{"join": strings.Join}).
+	ParseFS(templatesFS, "templates/site.html"))
+
+// htmlRenderer записывает страницы сайта в HTML
+type htmlRenderer struct {
+	site *Site
+}
+
+// htmlPage содержит данные шаблона страницы
+type htmlPage struct {
+	Kind      string
+	Title     string
+	SiteTitle string
+	Lang      string
+	Root      string
+	Config    *types.Config
+	Stats     *types.Statistics
+
+	PackageLinks linkGroup
+	TypeLinks    linkGroup
+	TagLinks     linkGroup
+
+	Description string
+	Tests       []htmlTest
+	Entries     []entry
+}
+
+// linkGroup - озаглавленный список ссылок
+type linkGroup struct {
+	Title string
+	Links []link
+}
+
+// htmlTest содержит данные теста для страницы пакета
+type htmlTest struct {
+	Test     types.TestInfo
+	Anchor   string
+	TypeName string
+	TypeURL  string
+	Tags     []link
+	// Priority и Severity - бейджи приоритета и серьезности
+	Priority string
+	Severity string
+}
+
+// index записывает главную страницу
+func (r *htmlRenderer) index(w io.Writer, idx *index) error {
+	pagePath := r.site.pagePath("", "index")
+	packages, testTypes, tags := r.site.links(idx)
+
+	p := r.newPage("index", r.site.config.Title, pagePath)
+	p.Stats = &idx.result.Stats
+	p.PackageLinks = linkGroup{Title: "Пакеты", Links: relativeLinks(pagePath, packages)}
+	p.TypeLinks = linkGroup{Title: "Типы тестов", Links: relativeLinks(pagePath, testTypes)}
+	p.TagLinks = linkGroup{Title: "Теги", Links: relativeLinks(pagePath, tags)}
+
+	return pageTemplate.ExecuteTemplate(w, "layout", p)
+}
+
+// pkg записывает страницу пакета с описаниями тестов
+func (r *htmlRenderer) pkg(w io.Writer, idx *index, pkg *types.PackageInfo) error {
+	pagePath := r.site.pagePath(packagesDir, pkg.Name)
+
+	p := r.newPage("package", "Пакет "+pkg.Name, pagePath)
+	p.Description = pkg.Description
+
+	anchors := r.site.testAnchors(pkg)
+	for i, test := range r.site.sortedTests(pkg.Tests) {
+		view := htmlTest{
+			Test:     test,
+			Anchor:   anchors[i],
+			TypeName: r.site.generator.TestTypeDisplayName(test.Type),
+			TypeURL:  relative(pagePath, r.site.pagePath(typesDir, string(test.Type))),
+			Priority: r.site.generator.PriorityBadge(test.Priority),
+			Severity: r.site.generator.SeverityBadge(test.Severity),
+		}
+		for _, tag := range test.Tags {
+			if tag != "" {
+				view.Tags = append(view.Tags, link{Title: tag, URL: relative(pagePath, r.site.pagePath(tagsDir, tag))})
+			}
+		}
+		p.Tests = append(p.Tests, view)
+	}
+
+	return pageTemplate.ExecuteTemplate(w, "layout", p)
+}
+
+// list записывает страницу типа или тега
+func (r *htmlRenderer) list(w io.Writer, title string, entries []entry) error {
+	// Все страницы списков находятся на одном уровне вложенности
+	pagePath := r.site.pagePath(typesDir, "_")
+
+	p := r.newPage("list", title, pagePath)
+	for _, e := range entries {
+		p.Entries = append(p.Entries, entry{Test: e.Test, URL: relative(pagePath, e.URL)})
+	}
+
+	return pageTemplate.ExecuteTemplate(w, "layout", p)
+}
+
+// newPage заполняет общие данные страницы
+func (r *htmlRenderer) newPage(kind, title, pagePath string) *htmlPage {
+	lang := r.site.config.Language
+	if lang == "" {
+		lang = "ru"
+	}
+
+	return &htmlPage{
+		Kind:      kind,
+		Title:     title,
+		SiteTitle: r.site.config.Title,
+		Lang:      lang,
+		Root:      relative(pagePath, ""),
+		Config:    r.site.config,
+	}
+}
+
+// writeAssets копирует скрипт поиска и стили, а также записывает индекс
+// в виде скрипта для открытия сайта без веб-сервера
+func (s *Site) writeAssets(dir string, entries []SearchEntry) error {
+	if err := os.MkdirAll(filepath.Join(dir, assetsDir), 0755); err != nil {
+		return err
+	}
+
+	for _, name := range []string{"search.js", "style.css"} {
+		data, err := assetsFS.ReadFile("assets/" + name)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, assetsDir, name), data, 0644); err != nil {
+			return err
+		}
+	}
+
+	return writeFile(filepath.Join(dir, assetsDir, searchIndexScriptFile), func(w io.Writer) error {
+		return writeSearchIndex(w, entries, "TESTDOC_SEARCH_INDEX")
+	})
+}
+
+// relativeLinks переводит адреса ссылок от корня сайта в адреса относительно страницы
+func relativeLinks(pagePath string, links []link) []link {
+	result := make([]link, len(links))
+	for i, l := range links {
+		result[i] = link{Title: l.Title, URL: relative(pagePath, l.URL), Count: l.Count}
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+package site
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// markdownRenderer записывает страницы сайта в Markdown с front matter,
+// который понимают MkDocs и Hugo. Ссылки ведут на .md файлы относительно страницы.
+type markdownRenderer struct {
+	site *Site
+}
+
+// index записывает главную страницу: статистику и ссылки на пакеты, типы и теги
+func (r *markdownRenderer) index(w io.Writer, idx *index) error {
+	config := r.site.config
+	pagePath := r.site.pagePath("", "index")
+
+	var sb strings.Builder
+	writeFrontMatter(&sb, config.Title)
+	sb.WriteString(fmt.Sprintf("# %s\n\n", config.Title))
+	sb.WriteString(fmt.Sprintf("**Автор:** %s  \n", config.Author))
+	sb.WriteString(fmt.Sprintf("**Версия:** %s\n\n", config.Version))
+	sb.WriteString("## Статистика тестов\n\n")
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return err
+	}
+
+	if err := r.site.generator.WriteStatistics(w, &idx.result.Stats); err != nil {
+		return err
+	}
+
+	sb.Reset()
+	packages, testTypes, tags := r.site.links(idx)
+	writeLinkList(&sb, "Пакеты", pagePath, packages)
+	writeLinkList(&sb, "Типы тестов", pagePath, testTypes)
+	writeLinkList(&sb, "Теги", pagePath, tags)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// pkg записывает страницу пакета: сводную таблицу со ссылками и описания тестов
+func (r *markdownRenderer) pkg(w io.Writer, idx *index, pkg *types.PackageInfo) error {
+	pagePath := r.site.pagePath(packagesDir, pkg.Name)
+	tests := r.site.sortedTests(pkg.Tests)
+
+	var sb strings.Builder
+	writeFrontMatter(&sb, "Пакет "+pkg.Name)
+	sb.WriteString(fmt.Sprintf("# Пакет %s\n\n", pkg.Name))
+	sb.WriteString(fmt.Sprintf("[← К оглавлению](%s)\n\n", relative(pagePath, r.site.pagePath("", "index"))))
+	if pkg.Description != "" {
+		sb.WriteString(pkg.Description + "\n\n")
+	}
+
+	sb.WriteString("| Тест | Тип | Теги |\n")
+	sb.WriteString("|------|-----|------|\n")
+	anchors := r.site.testAnchors(pkg)
+	for i, test := range tests {
+		tagLinks := make([]string, 0, len(test.Tags))
+		for _, tag := range test.Tags {
+			if tag != "" {
+				tagLinks = append(tagLinks, fmt.Sprintf("[%s](%s)", tag, relative(pagePath, r.site.pagePath(tagsDir, tag))))
+			}
+		}
+		sb.WriteString(fmt.Sprintf("| [%s](#%s) | [%s](%s) | %s |\n",
+			test.Name, anchors[i],
+			r.site.generator.TestTypeDisplayName(test.Type), relative(pagePath, r.site.pagePath(typesDir, string(test.Type))),
+			strings.Join(tagLinks, ", ")))
+	}
+	sb.WriteString("\n")
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return err
+	}
+
+	for _, test := range tests {
+		if err := r.site.generator.WriteTestSection(w, test); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// list записывает страницу типа или тега: таблицу тестов со ссылками на страницы пакетов
+func (r *markdownRenderer) list(w io.Writer, title string, entries []entry) error {
+	// Все страницы списков находятся на одном уровне вложенности
+	pagePath := r.site.pagePath(typesDir, "_")
+
+	var sb strings.Builder
+	writeFrontMatter(&sb, title)
+	sb.WriteString(fmt.Sprintf("# %s\n\n", title))
+	sb.WriteString(fmt.Sprintf("[← К оглавлению](%s)\n\n", relative(pagePath, r.site.pagePath("", "index"))))
+
+	sb.WriteString("| Тест | Пакет | Описание |\n")
+	sb.WriteString("|------|-------|----------|\n")
+	for _, e := range entries {
+		sb.WriteString(fmt.Sprintf("| [%s](%s) | `%s` | %s |\n",
+			e.Test.Name, relative(pagePath, e.URL), e.Test.Package, tableCell(e.Test.Description)))
+	}
+	sb.WriteString("\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeFrontMatter записывает YAML front matter с заголовком страницы
+func writeFrontMatter(sb *strings.Builder, title string) {
+	sb.WriteString("---\n")
+	sb.WriteString(fmt.Sprintf("title: %s\n", strconv.Quote(title)))
+	sb.WriteString("---\n\n")
+}
+
+// writeLinkList записывает раздел со списком ссылок и количеством тестов
+func writeLinkList(sb *strings.Builder, title, pagePath string, links []link) {
+	if len(links) == 0 {
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("## %s\n\n", title))
+	for _, l := range links {
+		sb.WriteString(fmt.Sprintf("- [%s](%s) (%d)\n", l.Title, relative(pagePath, l.URL), l.Count))
+	}
+	sb.WriteString("\n")
+}
+
+// tableCell приводит текст к виду, допустимому в ячейке Markdown таблицы
+func tableCell(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.ReplaceAll(text, "|", "\\|")
+}
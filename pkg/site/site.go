@@ -0,0 +1,455 @@
+// Package site генерирует многостраничную документацию тестов: главную
+// страницу, страницы пакетов, типов тестов и тегов, а также поисковый индекс.
+// Сайт строится без сетевых зависимостей в виде HTML или дерева Markdown,
+// совместимого с MkDocs и Hugo.
+package site
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/seblex/testdoc/pkg/generator"
+	"github.com/seblex/testdoc/pkg/slug"
+	"github.com/seblex/testdoc/pkg/types"
+)
+
+// Format - имя формата вывода многостраничного сайта
+const Format = "site"
+
+// Режимы генерации сайта
+const (
+	// ModeHTML - статические HTML страницы с встроенным поиском
+	ModeHTML = "html"
+	// ModeMarkdown - дерево Markdown файлов для MkDocs или Hugo
+	ModeMarkdown = "markdown"
+)
+
+// Директории страниц внутри сайта
+const (
+	packagesDir = "packages"
+	typesDir    = "types"
+	tagsDir     = "tags"
+	assetsDir   = "assets"
+)
+
+// Имена файлов поискового индекса
+const (
+	searchIndexFile       = "search-index.json"
+	searchIndexScriptFile = "search-index.js"
+)
+
+// Site генерирует многостраничную документацию
+type Site struct {
+	config      *types.Config
+	mode        string
+	anchorStyle slug.Style
+	generator   *generator.Generator
+	// files - имена файлов страниц по директориям: имя пакета, типа или тега -> файл
+	files map[string]map[string]string
+}
+
+// New создает генератор сайта. Пустой режим означает ModeHTML.
+func New(config *types.Config, mode string) (*Site, error) {
+	if config == nil {
+		config = types.DefaultConfig()
+	}
+
+	switch mode {
+	case "":
+		mode = ModeHTML
+	case ModeHTML, ModeMarkdown:
+	default:
+		return nil, fmt.Errorf("неизвестный режим сайта %q (доступны: %s, %s)", mode, ModeHTML, ModeMarkdown)
+	}
+
+	anchorStyle, err := slug.ParseStyle(config.AnchorStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Site{
+		config:      config,
+		mode:        mode,
+		anchorStyle: anchorStyle,
+		generator:   generator.New(config),
+	}, nil
+}
+
+// SearchEntry описывает тест в поисковом индексе сайта
+type SearchEntry struct {
+	Title       string   `json:"title"`
+	Package     string   `json:"package"`
+	Type        string   `json:"type"`
+	Tags        []string `json:"tags,omitempty"`
+	Description string   `json:"description,omitempty"`
+	URL         string   `json:"url"`
+}
+
+// link представляет ссылку на страницу сайта
+type link struct {
+	Title string
+	URL   string
+	Count int
+}
+
+// entry связывает тест с адресом его описания на странице пакета
+type entry struct {
+	Test types.TestInfo
+	URL  string
+}
+
+// index содержит данные, общие для всех страниц сайта
+type index struct {
+	result   *types.ParseResult
+	packages []string
+	types    []types.TestType
+	tags     []string
+	byType   map[types.TestType][]entry
+	byTag    map[string][]entry
+	entries  []entry
+}
+
+// Write записывает сайт в директорию dir, создавая ее при необходимости
+func (s *Site) Write(dir string, result *types.ParseResult) error {
+	for _, sub := range []string{"", packagesDir, typesDir, tagsDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return err
+		}
+	}
+
+	idx := s.buildIndex(result)
+	pages := s.pages(idx)
+
+	for _, p := range pages {
+		if err := writeFile(filepath.Join(dir, filepath.FromSlash(p.path)), p.write); err != nil {
+			return err
+		}
+	}
+
+	entries := s.SearchIndex(result)
+	if err := writeFile(filepath.Join(dir, searchIndexFile), func(w io.Writer) error {
+		return writeSearchIndex(w, entries, "")
+	}); err != nil {
+		return err
+	}
+
+	if s.mode == ModeHTML {
+		return s.writeAssets(dir, entries)
+	}
+	return nil
+}
+
+// SearchIndex возвращает поисковый индекс сайта: по записи на тест.
+// Адреса указаны относительно корня сайта.
+func (s *Site) SearchIndex(result *types.ParseResult) []SearchEntry {
+	idx := s.buildIndex(result)
+
+	entries := make([]SearchEntry, 0, len(idx.entries))
+	for _, e := range idx.entries {
+		entries = append(entries, SearchEntry{
+			Title:       e.Test.Name,
+			Package:     e.Test.Package,
+			Type:        string(e.Test.Type),
+			Tags:        e.Test.Tags,
+			Description: e.Test.Description,
+			URL:         e.URL,
+		})
+	}
+	return entries
+}
+
+// page описывает одну страницу сайта
+type page struct {
+	path  string
+	write func(w io.Writer) error
+}
+
+// pages возвращает все страницы сайта в режиме генерации
+func (s *Site) pages(idx *index) []page {
+	var r renderer
+	if s.mode == ModeHTML {
+		r = &htmlRenderer{site: s}
+	} else {
+		r = &markdownRenderer{site: s}
+	}
+
+	pages := []page{{
+		path:  s.pagePath("", "index"),
+		write: func(w io.Writer) error { return r.index(w, idx) },
+	}}
+
+	for _, name := range idx.packages {
+		pkg := idx.result.Packages[name]
+		pages = append(pages, page{
+			path:  s.pagePath(packagesDir, name),
+			write: func(w io.Writer) error { return r.pkg(w, idx, pkg) },
+		})
+	}
+
+	for _, testType := range idx.types {
+		title := s.generator.TestTypeDisplayName(testType) + " тесты"
+		entries := idx.byType[testType]
+		pages = append(pages, page{
+			path:  s.pagePath(typesDir, string(testType)),
+			write: func(w io.Writer) error { return r.list(w, title, entries) },
+		})
+	}
+
+	for _, tag := range idx.tags {
+		title := "Тег " + tag
+		entries := idx.byTag[tag]
+		pages = append(pages, page{
+			path:  s.pagePath(tagsDir, tag),
+			write: func(w io.Writer) error { return r.list(w, title, entries) },
+		})
+	}
+
+	return pages
+}
+
+// renderer записывает страницы сайта в определенном режиме
+type renderer interface {
+	index(w io.Writer, idx *index) error
+	pkg(w io.Writer, idx *index, pkg *types.PackageInfo) error
+	list(w io.Writer, title string, entries []entry) error
+}
+
+// buildIndex группирует тесты по пакетам, типам и тегам
+func (s *Site) buildIndex(result *types.ParseResult) *index {
+	idx := &index{
+		result: result,
+		byType: make(map[types.TestType][]entry),
+		byTag:  make(map[string][]entry),
+	}
+
+	for name := range result.Packages {
+		idx.packages = append(idx.packages, name)
+	}
+	sort.Strings(idx.packages)
+	s.assignFiles(result)
+
+	for _, name := range idx.packages {
+		pkg := result.Packages[name]
+		anchors := s.testAnchors(pkg)
+		for i, test := range s.sortedTests(pkg.Tests) {
+			e := entry{
+				Test: test,
+				URL:  s.pagePath(packagesDir, name) + "#" + anchors[i],
+			}
+			idx.entries = append(idx.entries, e)
+
+			if _, ok := idx.byType[test.Type]; !ok {
+				idx.types = append(idx.types, test.Type)
+			}
+			idx.byType[test.Type] = append(idx.byType[test.Type], e)
+
+			for _, tag := range test.Tags {
+				if tag == "" {
+					continue
+				}
+				if _, ok := idx.byTag[tag]; !ok {
+					idx.tags = append(idx.tags, tag)
+				}
+				idx.byTag[tag] = append(idx.byTag[tag], e)
+			}
+		}
+	}
+
+	sort.Slice(idx.types, func(i, j int) bool { return idx.types[i] < idx.types[j] })
+	sort.Strings(idx.tags)
+
+	return idx
+}
+
+// pagePath возвращает путь страницы относительно корня сайта
+func (s *Site) pagePath(dir, name string) string {
+	ext := ".html"
+	if s.mode == ModeMarkdown {
+		ext = ".md"
+	}
+	file, ok := s.files[dir][name]
+	if !ok {
+		file = fileName(name)
+	}
+	return path.Join(dir, file+ext)
+}
+
+// assignFiles назначает страницам пакетов, типов и тегов уникальные имена
+// файлов в каждой директории
+func (s *Site) assignFiles(result *types.ParseResult) {
+	var packages, testTypes, tags []string
+	seenTypes := make(map[string]bool)
+	seenTags := make(map[string]bool)
+	for name, pkg := range result.Packages {
+		packages = append(packages, name)
+		for _, test := range pkg.Tests {
+			if !seenTypes[string(test.Type)] {
+				seenTypes[string(test.Type)] = true
+				testTypes = append(testTypes, string(test.Type))
+			}
+			for _, tag := range test.Tags {
+				if tag != "" && !seenTags[tag] {
+					seenTags[tag] = true
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+
+	s.files = map[string]map[string]string{
+		packagesDir: uniqueFileNames(packages),
+		typesDir:    uniqueFileNames(testTypes),
+		tagsDir:     uniqueFileNames(tags),
+	}
+}
+
+// uniqueFileNames сопоставляет именам файлы fileName. Имена обрабатываются
+// по алфавиту; при совпадении файлов следующее имя получает суффикс "-1", "-2".
+func uniqueFileNames(names []string) map[string]string {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+
+	files := make(map[string]string, len(sorted))
+	seen := make(map[string]bool, len(sorted))
+	for _, name := range sorted {
+		base := fileName(name)
+		file := base
+		for i := 1; seen[file]; i++ {
+			file = fmt.Sprintf("%s-%d", base, i)
+		}
+		seen[file] = true
+		files[name] = file
+	}
+	return files
+}
+
+// links возвращает ссылки на страницы пакетов, типов и тегов относительно корня сайта
+func (s *Site) links(idx *index) (packages, testTypes, tags []link) {
+	for _, name := range idx.packages {
+		packages = append(packages, link{
+			Title: name,
+			URL:   s.pagePath(packagesDir, name),
+			Count: len(idx.result.Packages[name].Tests),
+		})
+	}
+	for _, testType := range idx.types {
+		testTypes = append(testTypes, link{
+			Title: s.generator.TestTypeDisplayName(testType),
+			URL:   s.pagePath(typesDir, string(testType)),
+			Count: len(idx.byType[testType]),
+		})
+	}
+	for _, tag := range idx.tags {
+		tags = append(tags, link{
+			Title: tag,
+			URL:   s.pagePath(tagsDir, tag),
+			Count: len(idx.byTag[tag]),
+		})
+	}
+	return packages, testTypes, tags
+}
+
+// sortedTests возвращает копию тестов, упорядоченную по sort_by и sort_order;
+// без sort_by - по имени
+func (s *Site) sortedTests(tests []types.TestInfo) []types.TestInfo {
+	sorted := make([]types.TestInfo, len(tests))
+	copy(sorted, tests)
+
+	by := s.config.SortBy
+	if by == "" {
+		by = generator.SortName
+	}
+	generator.SortTests(sorted, by, s.config.SortOrder)
+	return sorted
+}
+
+// fileName преобразует имя пакета или тега в имя файла: строчные буквы,
+// цифры, "-" и "_"; остальные символы заменяются на "-"
+func fileName(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteRune('-')
+			dash = true
+		}
+	}
+
+	result := strings.TrimRight(sb.String(), "-")
+	if result == "" {
+		return "_"
+	}
+	return result
+}
+
+// testAnchors возвращает якоря заголовков тестов на странице пакета
+// в порядке sortedTests. Повторяющиеся имена получают суффиксы "-1", "-2".
+func (s *Site) testAnchors(pkg *types.PackageInfo) []string {
+	slugger := slug.New(s.anchorStyle)
+	slugger.Slug("Пакет " + pkg.Name)
+
+	tests := s.sortedTests(pkg.Tests)
+	anchors := make([]string, len(tests))
+	for i, test := range tests {
+		anchors[i] = slugger.Slug(test.Name)
+	}
+	return anchors
+}
+
+// relative возвращает адрес target относительно страницы from (оба - от корня сайта)
+func relative(from, target string) string {
+	depth := strings.Count(from, "/")
+	return strings.Repeat("../", depth) + target
+}
+
+// writeFile создает файл и записывает его содержимое через буфер
+func writeFile(filename string, write func(w io.Writer) error) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	buf := bufio.NewWriter(f)
+	if err := write(buf); err != nil {
+		f.Close()
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeSearchIndex записывает индекс в JSON. Если variable не пустая,
+// индекс записывается как JavaScript присваивание для работы без сервера.
+func writeSearchIndex(w io.Writer, entries []SearchEntry, variable string) error {
+	if variable != "" {
+		if _, err := fmt.Fprintf(w, "window.%s = ", variable); err != nil {
+			return err
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(entries); err != nil {
+		return err
+	}
+
+	if variable != "" {
+		_, err := io.WriteString(w, ";\n")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package site
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seblex/testdoc/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createSiteResult() *types.ParseResult {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"auth": {
+				Name:        "auth",
+				Description: "Аутентификация",
+				Tests: []types.TestInfo{
+					{Name: "TestLogin", Type: types.UnitTest, Package: "auth", File: "auth_test.go", Line: 10,
+						Description: "Проверяет вход", Tags: []string{"smoke", "auth"}, Priority: types.P0, Severity: types.SeverityCritical},
+					{Name: "TestLogout", Type: types.IntegrationTest, Package: "auth", File: "auth_test.go", Line: 30,
+						Tags: []string{"auth"}},
+				},
+			},
+			"api/v1": {
+				Name: "api/v1",
+				Tests: []types.TestInfo{
+					{Name: "TestHandler", Type: types.UnitTest, Package: "api/v1", File: "handler_test.go", Line: 5,
+						Skipped: true, SkipReason: "нестабилен"},
+				},
+			},
+		},
+	}
+	result.CalculateStats()
+	return result
+}
+
+func TestNew(t *testing.T) {
+	s, err := New(nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, ModeHTML, s.mode)
+
+	s, err = New(types.DefaultConfig(), ModeMarkdown)
+	require.NoError(t, err)
+	assert.Equal(t, ModeMarkdown, s.mode)
+
+	_, err = New(types.DefaultConfig(), "pdf")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"pdf"`)
+}
+
+func TestSite_WriteHTML(t *testing.T) {
+	dir := t.TempDir()
+	s, err := New(types.DefaultConfig(), ModeHTML)
+	require.NoError(t, err)
+	require.NoError(t, s.Write(dir, createSiteResult()))
+
+	for _, name := range []string{
+		"index.html",
+		"packages/auth.html",
+		"packages/api-v1.html",
+		"types/unit.html",
+		"types/integration.html",
+		"tags/smoke.html",
+		"tags/auth.html",
+		"search-index.json",
+		"assets/search.js",
+		"assets/style.css",
+		"assets/search-index.js",
+	} {
+		assert.FileExists(t, filepath.Join(dir, filepath.FromSlash(name)))
+	}
+
+	index := readFile(t, dir, "index.html")
+	assert.Contains(t, index, `href="packages/auth.html"`)
+	assert.Contains(t, index, `href="tags/smoke.html"`)
+	assert.Contains(t, index, `src="assets/search.js"`)
+
+	pkg := readFile(t, dir, "packages/auth.html")
+	assert.Contains(t, pkg, `id="testlogin"`)
+	assert.Contains(t, pkg, `href="../types/unit.html"`)
+	assert.Contains(t, pkg, `href="../tags/smoke.html"`)
+	assert.Contains(t, pkg, `href="../assets/style.css"`)
+	assert.Contains(t, pkg, "Проверяет вход")
+	assert.Contains(t, pkg, `<td class="priority priority-P0">🔴 P0</td>`)
+	assert.Contains(t, pkg, `<td class="severity severity-critical">🔴 Критическая</td>`)
+
+	list := readFile(t, dir, "tags/auth.html")
+	assert.Contains(t, list, `href="../packages/auth.html#testlogin"`)
+	assert.Contains(t, list, `href="../packages/auth.html#testlogout"`)
+
+	script := readFile(t, dir, "assets/search-index.js")
+	assert.Contains(t, script, "window.TESTDOC_SEARCH_INDEX = [")
+}
+
+func TestSite_WriteMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	s, err := New(types.DefaultConfig(), ModeMarkdown)
+	require.NoError(t, err)
+	require.NoError(t, s.Write(dir, createSiteResult()))
+
+	index := readFile(t, dir, "index.md")
+	assert.Contains(t, index, "---\ntitle: ")
+	assert.Contains(t, index, "- [auth](packages/auth.md) (2)")
+	assert.Contains(t, index, "- [smoke](tags/smoke.md) (1)")
+
+	pkg := readFile(t, dir, "packages/auth.md")
+	assert.Contains(t, pkg, "# Пакет auth")
+	assert.Contains(t, pkg, "[← К оглавлению](../index.md)")
+	assert.Contains(t, pkg, "| [TestLogin](#testlogin) | [Модульные](../types/unit.md) | [smoke](../tags/smoke.md), [auth](../tags/auth.md) |")
+	assert.Contains(t, pkg, "### TestLogin")
+
+	list := readFile(t, dir, "types/unit.md")
+	assert.Contains(t, list, "[TestHandler](../packages/api-v1.md#testhandler)")
+
+	assert.NoFileExists(t, filepath.Join(dir, "assets", "search.js"))
+	assert.FileExists(t, filepath.Join(dir, "search-index.json"))
+}
+
+func TestSite_SearchIndex(t *testing.T) {
+	s, err := New(types.DefaultConfig(), ModeHTML)
+	require.NoError(t, err)
+
+	entries := s.SearchIndex(createSiteResult())
+	require.Len(t, entries, 3)
+	assert.Equal(t, "TestHandler", entries[0].Title)
+	assert.Equal(t, "packages/api-v1.html#testhandler", entries[0].URL)
+	assert.Equal(t, "TestLogin", entries[1].Title)
+	assert.Equal(t, []string{"smoke", "auth"}, entries[1].Tags)
+
+	dir := t.TempDir()
+	require.NoError(t, s.Write(dir, createSiteResult()))
+
+	var decoded []SearchEntry
+	require.NoError(t, json.Unmarshal([]byte(readFile(t, dir, "search-index.json")), &decoded))
+	assert.Equal(t, entries, decoded)
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"auth", "auth"},
+		{"api/v1", "api-v1"},
+		{"Smoke Test", "smoke-test"},
+		{"критичный", "критичный"},
+		{"snake_case", "snake_case"},
+		{"/", "_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fileName(tt.name))
+		})
+	}
+}
+
+func TestSite_WriteHTML_CollidingNames(t *testing.T) {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"auth": {Name: "auth", Tests: []types.TestInfo{
+				{Name: "TestLogin", Type: types.UnitTest, Package: "auth", Tags: []string{"api/v1"}},
+				{Name: "TestLogout", Type: types.UnitTest, Package: "auth", Tags: []string{"api v1"}},
+			}},
+		},
+	}
+	result.CalculateStats()
+
+	dir := t.TempDir()
+	s, err := New(types.DefaultConfig(), ModeHTML)
+	require.NoError(t, err)
+	require.NoError(t, s.Write(dir, result))
+
+	// Теги "api v1" и "api/v1" дают одно имя файла; второй получает суффикс
+	first := readFile(t, dir, "tags/api-v1.html")
+	assert.Contains(t, first, "Тег api v1")
+	assert.Contains(t, first, "#testlogout")
+	second := readFile(t, dir, "tags/api-v1-1.html")
+	assert.Contains(t, second, "Тег api/v1")
+	assert.Contains(t, second, "#testlogin")
+
+	index := readFile(t, dir, "index.html")
+	assert.Contains(t, index, `href="tags/api-v1.html"`)
+	assert.Contains(t, index, `href="tags/api-v1-1.html"`)
+}
+
+func TestUniqueFileNames(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"a b": "a-b",
+		"a-b": "a-b-1",
+		"a/b": "a-b-2",
+		"c":   "c",
+	}, uniqueFileNames([]string{"c", "a/b", "a-b", "a b"}))
+}
+
+func TestRelative(t *testing.T) {
+	assert.Equal(t, "packages/auth.html", relative("index.html", "packages/auth.html"))
+	assert.Equal(t, "../tags/smoke.html", relative("packages/auth.html", "tags/smoke.html"))
+	assert.Equal(t, "../", relative("types/unit.html", ""))
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestSite_testAnchors(t *testing.T) {
+	pkg := &types.PackageInfo{
+		Name: "platform",
+		Tests: []types.TestInfo{
+			{Name: "TestRun_Linux"},
+			{Name: "TestRun_Linux"},
+			{Name: "TestA"},
+		},
+	}
+
+	s, err := New(types.DefaultConfig(), ModeHTML)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"testa", "testrun_linux", "testrun_linux-1"}, s.testAnchors(pkg))
+
+	_, err = New(&types.Config{AnchorStyle: "bitbucket"}, ModeHTML)
+	assert.Error(t, err)
+}
+
+func TestSite_sortedTests(t *testing.T) {
+	tests := []types.TestInfo{
+		{Name: "TestB", File: "a_test.go", Line: 1},
+		{Name: "TestA", File: "a_test.go", Line: 20},
+	}
+
+	s, err := New(types.DefaultConfig(), ModeHTML)
+	require.NoError(t, err)
+	assert.Equal(t, "TestA", s.sortedTests(tests)[0].Name)
+
+	config := types.DefaultConfig()
+	config.SortBy = "source"
+	s, err = New(config, ModeHTML)
+	require.NoError(t, err)
+	assert.Equal(t, "TestB", s.sortedTests(tests)[0].Name)
+	// Исходный срез не меняется
+	assert.Equal(t, "TestB", tests[0].Name)
+}
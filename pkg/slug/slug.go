@@ -0,0 +1,100 @@
+// Package slug формирует якоря заголовков Markdown так же, как это делают GitHub и GitLab
+package slug
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Style определяет алгоритм формирования якорей
+type Style string
+
+// Поддерживаемые алгоритмы
+const (
+	// GitHub - алгоритм github-slugger: каждый пробел заменяется на "-",
+	// подчеркивания и повторные дефисы сохраняются
+	GitHub Style = "github"
+	// GitLab - алгоритм GitLab: пробелы заменяются на "-", повторные дефисы схлопываются
+	GitLab Style = "gitlab"
+)
+
+// Styles возвращает список поддерживаемых алгоритмов
+func Styles() []Style {
+	return []Style{GitHub, GitLab}
+}
+
+// ParseStyle разбирает название алгоритма. Пустая строка означает GitHub.
+func ParseStyle(value string) (Style, error) {
+	switch Style(strings.ToLower(value)) {
+	case "", GitHub:
+		return GitHub, nil
+	case GitLab:
+		return GitLab, nil
+	default:
+		return "", fmt.Errorf("неизвестный стиль якорей %q (доступны: %s, %s)", value, GitHub, GitLab)
+	}
+}
+
+// Make возвращает якорь заголовка без учета повторов
+func Make(style Style, heading string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case r == ' ' || r == '-':
+			// GitLab схлопывает последовательности дефисов
+			if style == GitLab && strings.HasSuffix(sb.String(), "-") {
+				continue
+			}
+			sb.WriteRune('-')
+		case isWordRune(r):
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// isWordRune проверяет, сохраняется ли символ в якоре: буквы, цифры,
+// диакритические знаки и соединительная пунктуация (например, "_")
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r) || unicode.Is(unicode.Pc, r)
+}
+
+// Slugger формирует уникальные в пределах документа якоря: повторный
+// заголовок получает суффикс "-1", "-2" и так далее
+type Slugger struct {
+	style Style
+	seen  map[string]int
+}
+
+// New создает генератор якорей для одного документа
+func New(style Style) *Slugger {
+	return &Slugger{
+		style: style,
+		seen:  make(map[string]int),
+	}
+}
+
+// Slug возвращает уникальный якорь очередного заголовка документа
+func (s *Slugger) Slug(heading string) string {
+	base := Make(s.style, heading)
+	result := base
+
+	// Суффикс может совпасть с якорем другого заголовка, например "test-1"
+	for {
+		if _, ok := s.seen[result]; !ok {
+			break
+		}
+		s.seen[base]++
+		result = base + "-" + strconv.Itoa(s.seen[base])
+	}
+
+	s.seen[result] = 0
+	return result
+}
+
+// Reset забывает выданные якоря, чтобы начать новый документ
+func (s *Slugger) Reset() {
+	s.seen = make(map[string]int)
+}
@@ -0,0 +1,67 @@
+package slug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    Style
+		heading  string
+		expected string
+	}{
+		{"github underscores", GitHub, "Test_Login_Success", "test_login_success"},
+		{"github cyrillic", GitHub, "Пакет auth", "пакет-auth"},
+		{"github punctuation", GitHub, "Пакет api/v1.2", "пакет-apiv12"},
+		{"github owner", GitHub, "Владелец @org/team", "владелец-orgteam"},
+		{"github double space", GitHub, "Модульные  тесты", "модульные--тесты"},
+		{"github dash sequence", GitHub, "a - b", "a---b"},
+		{"github code", GitHub, "Функция `Parse()`", "функция-parse"},
+		{"gitlab underscores", GitLab, "Test_Login_Success", "test_login_success"},
+		{"gitlab dash sequence", GitLab, "a - b", "a-b"},
+		{"gitlab double space", GitLab, "Модульные  тесты", "модульные-тесты"},
+		{"trim", GitHub, "  Title  ", "title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Make(tt.style, tt.heading))
+		})
+	}
+}
+
+func TestSlugger_Slug(t *testing.T) {
+	s := New(GitHub)
+
+	assert.Equal(t, "testa", s.Slug("TestA"))
+	assert.Equal(t, "testa-1", s.Slug("TestA"))
+	assert.Equal(t, "testa-2", s.Slug("TestA"))
+	assert.Equal(t, "testb", s.Slug("TestB"))
+
+	// Заголовок, совпадающий с уже выданным суффиксом
+	s = New(GitHub)
+	assert.Equal(t, "test-1", s.Slug("Test 1"))
+	assert.Equal(t, "test", s.Slug("Test"))
+	assert.Equal(t, "test-2", s.Slug("Test"))
+
+	s.Reset()
+	assert.Equal(t, "test", s.Slug("Test"))
+}
+
+func TestParseStyle(t *testing.T) {
+	style, err := ParseStyle("")
+	require.NoError(t, err)
+	assert.Equal(t, GitHub, style)
+
+	style, err = ParseStyle("GitLab")
+	require.NoError(t, err)
+	assert.Equal(t, GitLab, style)
+
+	_, err = ParseStyle("bitbucket")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"bitbucket"`)
+}
@@ -0,0 +1,91 @@
+package types
+
+// BadgeConfig содержит настройки SVG бейджей
+type BadgeConfig struct {
+	// Dir - директория для записи бейджей
+	Dir string `yaml:"dir"`
+	// Color - цвет бейджей с количеством тестов
+	Color string `yaml:"color"`
+	// Colors - цвета бейджей с порогами
+	Colors BadgeColors `yaml:"colors"`
+	// Coverage - пороги доли тестов, описанных аннотациями, в процентах
+	Coverage Threshold `yaml:"coverage"`
+	// PassRate - пороги доли прошедших тестов, в процентах
+	PassRate Threshold `yaml:"pass_rate"`
+	// Skipped - пороги количества пропущенных тестов; меньше - лучше
+	Skipped Threshold `yaml:"skipped"`
+}
+
+// BadgeColors задает цвета уровней: имя цвета shields.io или #rrggbb
+type BadgeColors struct {
+	Good    string `yaml:"good"`
+	Warning string `yaml:"warning"`
+	Bad     string `yaml:"bad"`
+}
+
+// Threshold задает границы уровней метрики
+type Threshold struct {
+	Good    float64 `yaml:"good"`
+	Warning float64 `yaml:"warning"`
+}
+
+// Level определяет уровень значения метрики
+type Level string
+
+const (
+	LevelGood    Level = "good"
+	LevelWarning Level = "warning"
+	LevelBad     Level = "bad"
+)
+
+// Higher возвращает уровень метрики, у которой большее значение лучше
+func (t Threshold) Higher(value float64) Level {
+	switch {
+	case value >= t.Good:
+		return LevelGood
+	case value >= t.Warning:
+		return LevelWarning
+	default:
+		return LevelBad
+	}
+}
+
+// Lower возвращает уровень метрики, у которой меньшее значение лучше
+func (t Threshold) Lower(value float64) Level {
+	switch {
+	case value <= t.Good:
+		return LevelGood
+	case value <= t.Warning:
+		return LevelWarning
+	default:
+		return LevelBad
+	}
+}
+
+// Color возвращает цвет уровня
+func (c BadgeColors) Color(level Level) string {
+	switch level {
+	case LevelGood:
+		return c.Good
+	case LevelWarning:
+		return c.Warning
+	default:
+		return c.Bad
+	}
+}
+
+// DefaultBadgeConfig возвращает настройки бейджей по умолчанию
+func DefaultBadgeConfig() BadgeConfig {
+	return BadgeConfig{
+		Dir:   "badges",
+		Color: "blue",
+		Colors: BadgeColors{
+			Good:    "brightgreen",
+			Warning: "yellow",
+			Bad:     "red",
+		},
+		Coverage: Threshold{Good: 80, Warning: 50},
+		PassRate: Threshold{Good: 95, Warning: 80},
+		Skipped:  Threshold{Good: 0, Warning: 5},
+	}
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreshold(t *testing.T) {
+	coverage := Threshold{Good: 80, Warning: 50}
+	assert.Equal(t, LevelGood, coverage.Higher(80))
+	assert.Equal(t, LevelWarning, coverage.Higher(79.9))
+	assert.Equal(t, LevelBad, coverage.Higher(10))
+
+	skipped := Threshold{Good: 0, Warning: 5}
+	assert.Equal(t, LevelGood, skipped.Lower(0))
+	assert.Equal(t, LevelWarning, skipped.Lower(5))
+	assert.Equal(t, LevelBad, skipped.Lower(6))
+}
+
+func TestBadgeColors_Color(t *testing.T) {
+	colors := DefaultBadgeConfig().Colors
+	assert.Equal(t, "brightgreen", colors.Color(LevelGood))
+	assert.Equal(t, "yellow", colors.Color(LevelWarning))
+	assert.Equal(t, "red", colors.Color(LevelBad))
+}
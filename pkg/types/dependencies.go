@@ -0,0 +1,135 @@
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// DependencyKind определяет вид внешнего ресурса, который использует тест
+type DependencyKind string
+
+const (
+	// DependencyHTTPServer - локальный HTTP сервер net/http/httptest
+	DependencyHTTPServer DependencyKind = "http_server"
+	// DependencyDatabase - база данных database/sql (Name - драйвер)
+	DependencyDatabase DependencyKind = "database"
+	// DependencyEnv - переменная окружения, читаемая тестом
+	DependencyEnv DependencyKind = "env"
+	// DependencySetenv - переменная окружения, устанавливаемая через t.Setenv
+	DependencySetenv DependencyKind = "setenv"
+	// DependencyTempDir - временная директория t.TempDir
+	DependencyTempDir DependencyKind = "temp_dir"
+	// DependencyContainer - контейнер testcontainers (Name - образ или модуль)
+	DependencyContainer DependencyKind = "container"
+	// DependencyDocker - вызов docker CLI или Docker API
+	DependencyDocker DependencyKind = "docker"
+	// DependencyNetwork - сетевое соединение net.Dial (Name - адрес)
+	DependencyNetwork DependencyKind = "network"
+	// DependencyCustom - произвольная зависимость из аннотации @requires
+	DependencyCustom DependencyKind = "custom"
+)
+
+// dependencyKinds перечисляет виды зависимостей в порядке вывода
+var dependencyKinds = []DependencyKind{
+	DependencyDatabase,
+	DependencyContainer,
+	DependencyDocker,
+	DependencyNetwork,
+	DependencyEnv,
+	DependencyCustom,
+	DependencyHTTPServer,
+	DependencySetenv,
+	DependencyTempDir,
+}
+
+// Dependency описывает внешний ресурс, от которого зависит тест
+type Dependency struct {
+	Kind DependencyKind `json:"kind" yaml:"kind"`
+	Name string         `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// ParseDependency разбирает значение вида "env:DATABASE_URL" или "postgres".
+// Если префикс не является известным видом, зависимость считается произвольной.
+func ParseDependency(value string) Dependency {
+	value = strings.TrimSpace(value)
+	if kind, name, ok := strings.Cut(value, ":"); ok {
+		for _, known := range dependencyKinds {
+			if DependencyKind(strings.TrimSpace(kind)) == known {
+				return Dependency{Kind: known, Name: strings.TrimSpace(name)}
+			}
+		}
+	}
+	for _, known := range dependencyKinds {
+		if DependencyKind(value) == known {
+			return Dependency{Kind: known}
+		}
+	}
+	return Dependency{Kind: DependencyCustom, Name: value}
+}
+
+// RequiresSetup возвращает true, если ресурс нужно подготовить до запуска тестов.
+// Временные директории, httptest и t.Setenv тест создает сам.
+func (d Dependency) RequiresSetup() bool {
+	switch d.Kind {
+	case DependencyHTTPServer, DependencySetenv, DependencyTempDir:
+		return false
+	default:
+		return true
+	}
+}
+
+// AddDependency добавляет зависимость, если такой еще нет у теста
+func (t *TestInfo) AddDependency(dep Dependency) {
+	for _, existing := range t.Dependencies {
+		if existing == dep {
+			return
+		}
+	}
+	t.Dependencies = append(t.Dependencies, dep)
+}
+
+// EnvironmentItem описывает пункт чек-листа окружения: ресурс и тесты, которым он нужен
+type EnvironmentItem struct {
+	Dependency Dependency `json:"dependency" yaml:"dependency"`
+	Tests      []string   `json:"tests" yaml:"tests"`
+}
+
+// Environment собирает ресурсы, которые нужно подготовить для запуска тестов.
+// Пункты отсортированы по виду зависимости и имени, тесты - по пакету и имени.
+func (pr *ParseResult) Environment() []EnvironmentItem {
+	items := make(map[Dependency]*EnvironmentItem)
+
+	for _, pkg := range pr.Packages {
+		for _, test := range pkg.Tests {
+			for _, dep := range test.Dependencies {
+				if !dep.RequiresSetup() {
+					continue
+				}
+				if items[dep] == nil {
+					items[dep] = &EnvironmentItem{Dependency: dep}
+				}
+				items[dep].Tests = append(items[dep].Tests, test.Package+"."+test.Name)
+			}
+		}
+	}
+
+	order := make(map[DependencyKind]int, len(dependencyKinds))
+	for i, kind := range dependencyKinds {
+		order[kind] = i
+	}
+
+	checklist := make([]EnvironmentItem, 0, len(items))
+	for _, item := range items {
+		sort.Strings(item.Tests)
+		checklist = append(checklist, *item)
+	}
+	sort.Slice(checklist, func(i, j int) bool {
+		a, b := checklist[i].Dependency, checklist[j].Dependency
+		if a.Kind != b.Kind {
+			return order[a.Kind] < order[b.Kind]
+		}
+		return a.Name < b.Name
+	})
+
+	return checklist
+}
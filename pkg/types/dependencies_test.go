@@ -0,0 +1,63 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDependency(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Dependency
+	}{
+		{"env:DATABASE_URL", Dependency{Kind: DependencyEnv, Name: "DATABASE_URL"}},
+		{" database : postgres ", Dependency{Kind: DependencyDatabase, Name: "postgres"}},
+		{"docker", Dependency{Kind: DependencyDocker}},
+		{"redis", Dependency{Kind: DependencyCustom, Name: "redis"}},
+		{"http://localhost:8080", Dependency{Kind: DependencyCustom, Name: "http://localhost:8080"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseDependency(tt.value))
+		})
+	}
+}
+
+func TestTestInfo_AddDependency(t *testing.T) {
+	var test TestInfo
+	test.AddDependency(Dependency{Kind: DependencyEnv, Name: "A"})
+	test.AddDependency(Dependency{Kind: DependencyEnv, Name: "A"})
+	test.AddDependency(Dependency{Kind: DependencySetenv, Name: "A"})
+
+	assert.Len(t, test.Dependencies, 2)
+}
+
+func TestParseResult_Environment(t *testing.T) {
+	env := Dependency{Kind: DependencyEnv, Name: "DATABASE_URL"}
+	db := Dependency{Kind: DependencyDatabase, Name: "postgres"}
+
+	result := &ParseResult{
+		Packages: map[string]*PackageInfo{
+			"store": {
+				Name: "store",
+				Tests: []TestInfo{
+					{Name: "TestSave", Package: "store", Dependencies: []Dependency{db, env}},
+					{Name: "TestLoad", Package: "store", Dependencies: []Dependency{env, {Kind: DependencyTempDir}}},
+				},
+			},
+			"api": {
+				Name: "api",
+				Tests: []TestInfo{
+					{Name: "TestHandler", Package: "api", Dependencies: []Dependency{{Kind: DependencyHTTPServer, Name: "httptest"}}},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []EnvironmentItem{
+		{Dependency: db, Tests: []string{"store.TestSave"}},
+		{Dependency: env, Tests: []string{"store.TestLoad", "store.TestSave"}},
+	}, result.Environment())
+}
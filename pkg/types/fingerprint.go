@@ -0,0 +1,89 @@
+package types
+
+import (
+	"hash/fnv"
+	"strings"
+)
+
+// FingerprintSize - количество хеш-функций в сигнатуре MinHash
+const FingerprintSize = 16
+
+// fingerprintShingle - длина последовательности токенов (шингла)
+const fingerprintShingle = 3
+
+// Fingerprint - сигнатура MinHash тела теста. Позволяет оценить сходство
+// тел двух тестов без хранения исходного кода, например для поиска
+// переименованных тестов при сравнении инвентаризаций.
+type Fingerprint []uint32
+
+// NewFingerprint вычисляет сигнатуру по последовательности токенов тела теста.
+// Для пустой последовательности возвращает nil.
+func NewFingerprint(tokens []string) Fingerprint {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	mins := make([]uint64, FingerprintSize)
+	for i := range mins {
+		mins[i] = ^uint64(0)
+	}
+
+	size := fingerprintShingle
+	if len(tokens) < size {
+		size = len(tokens)
+	}
+	for start := 0; start+size <= len(tokens); start++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(tokens[start:start+size], "\x00")))
+		shingle := h.Sum64()
+
+		for i := range mins {
+			if v := mix64(shingle ^ fingerprintSeeds[i]); v < mins[i] {
+				mins[i] = v
+			}
+		}
+	}
+
+	fingerprint := make(Fingerprint, FingerprintSize)
+	for i, v := range mins {
+		fingerprint[i] = uint32(v >> 32)
+	}
+	return fingerprint
+}
+
+// Similarity оценивает коэффициент Жаккара множеств шинглов: долю
+// совпадающих позиций сигнатур от 0 до 1. Пустые сигнатуры не похожи ни на что.
+func (f Fingerprint) Similarity(other Fingerprint) float64 {
+	if len(f) == 0 || len(f) != len(other) {
+		return 0
+	}
+
+	equal := 0
+	for i := range f {
+		if f[i] == other[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(f))
+}
+
+// fingerprintSeeds задают независимые хеш-функции сигнатуры
+var fingerprintSeeds = func() [FingerprintSize]uint64 {
+	var seeds [FingerprintSize]uint64
+	seed := uint64(0x9e3779b97f4a7c15)
+	for i := range seeds {
+		seed = mix64(seed + uint64(i))
+		seeds[i] = seed
+	}
+	return seeds
+}()
+
+// mix64 - финализатор SplitMix64, равномерно перемешивающий биты
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFingerprint(t *testing.T) {
+	assert.Nil(t, NewFingerprint(nil))
+
+	tokens := strings.Fields("x := compute ( 1 ) if x != 2 { t . Fatal ( x ) }")
+	f := NewFingerprint(tokens)
+	assert.Len(t, f, FingerprintSize)
+	assert.Equal(t, f, NewFingerprint(tokens))
+	assert.Equal(t, 1.0, f.Similarity(NewFingerprint(tokens)))
+
+	short := NewFingerprint([]string{"t"})
+	assert.Len(t, short, FingerprintSize)
+}
+
+func TestFingerprint_Similarity(t *testing.T) {
+	base := strings.Fields("user := NewUser ( name ) err := user . Validate ( ) require . NoError ( t , err ) assert . Equal ( t , name , user . Name ) assert . True ( t , user . Active )")
+	changed := append(append([]string{}, base...), strings.Fields("assert . Empty ( t , user . Roles )")...)
+	other := strings.Fields("resp , err := http . Get ( server . URL ) require . NoError ( t , err ) defer resp . Body . Close ( )")
+
+	f := NewFingerprint(base)
+	assert.Greater(t, f.Similarity(NewFingerprint(changed)), 0.5)
+	assert.Less(t, f.Similarity(NewFingerprint(other)), 0.3)
+
+	assert.Equal(t, 0.0, Fingerprint(nil).Similarity(f))
+	assert.Equal(t, 0.0, f.Similarity(Fingerprint{1, 2}))
+}
@@ -0,0 +1,168 @@
+package types
+
+import "sort"
+
+// QualityCriterion определяет критерий качества документации теста
+type QualityCriterion string
+
+const (
+	// CriterionDescription - тест имеет описание
+	CriterionDescription QualityCriterion = "description"
+	// CriterionType - тип указан аннотацией @type, а не выбран по умолчанию
+	CriterionType QualityCriterion = "type"
+	// CriterionAuthor - указан автор (@author)
+	CriterionAuthor QualityCriterion = "author"
+	// CriterionTestCases - описан хотя бы один тест-кейс
+	CriterionTestCases QualityCriterion = "test_cases"
+	// CriterionSteps - у каждого тест-кейса есть шаги или сценарий
+	CriterionSteps QualityCriterion = "steps"
+)
+
+// QualityCriteria перечисляет критерии в порядке вывода вместе с их весами.
+// Сумма весов равна 100, поэтому оценка теста находится в диапазоне 0..100.
+var QualityCriteria = []struct {
+	Criterion QualityCriterion
+	Weight    float64
+}{
+	{CriterionDescription, 35},
+	{CriterionType, 15},
+	{CriterionAuthor, 10},
+	{CriterionTestCases, 25},
+	{CriterionSteps, 15},
+}
+
+// WorstTestsLimit - количество худших по качеству тестов в статистике
+const WorstTestsLimit = 10
+
+// QualityStats содержит метрики качества документации тестов
+type QualityStats struct {
+	// Coverage - количество тестов, удовлетворяющих каждому критерию
+	Coverage map[QualityCriterion]int `json:"coverage" yaml:"coverage"`
+	// Score - средняя оценка тестов проекта, 0..100
+	Score float64 `json:"score" yaml:"score"`
+	// Packages - средняя оценка тестов каждого пакета
+	Packages map[string]float64 `json:"packages" yaml:"packages"`
+	// Worst - тесты с наименьшей оценкой, не более WorstTestsLimit
+	Worst []TestQuality `json:"worst,omitempty" yaml:"worst,omitempty"`
+}
+
+// TestQuality содержит оценку документации одного теста
+type TestQuality struct {
+	Package string             `json:"package" yaml:"package"`
+	Name    string             `json:"name" yaml:"name"`
+	Score   float64            `json:"score" yaml:"score"`
+	Missing []QualityCriterion `json:"missing,omitempty" yaml:"missing,omitempty"`
+}
+
+// Satisfies проверяет, удовлетворяет ли тест критерию качества
+func (t TestInfo) Satisfies(criterion QualityCriterion) bool {
+	switch criterion {
+	case CriterionDescription:
+		return t.Description != ""
+	case CriterionType:
+		return t.ExplicitType
+	case CriterionAuthor:
+		return t.Author != ""
+	case CriterionTestCases:
+		return len(t.TestCases) > 0
+	case CriterionSteps:
+		if len(t.TestCases) == 0 {
+			return false
+		}
+		for _, testCase := range t.TestCases {
+			if len(testCase.Steps) == 0 && len(testCase.Scenario) == 0 {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// Quality вычисляет оценку документации теста и список невыполненных критериев
+func (t TestInfo) Quality() TestQuality {
+	quality := TestQuality{Package: t.Package, Name: t.Name}
+	for _, c := range QualityCriteria {
+		if t.Satisfies(c.Criterion) {
+			quality.Score += c.Weight
+		} else {
+			quality.Missing = append(quality.Missing, c.Criterion)
+		}
+	}
+	return quality
+}
+
+// CoveragePercent возвращает долю тестов, удовлетворяющих критерию, в процентах
+func (s Statistics) CoveragePercent(criterion QualityCriterion) float64 {
+	if s.TotalTests == 0 {
+		return 0
+	}
+	return float64(s.Quality.Coverage[criterion]) * 100 / float64(s.TotalTests)
+}
+
+// AnnotationCoverage возвращает покрытие тестов аннотациями: среднюю долю
+// выполнения критериев качества по Quality.Coverage, в процентах
+func (s Statistics) AnnotationCoverage() float64 {
+	total := 0.0
+	for _, c := range QualityCriteria {
+		total += s.CoveragePercent(c.Criterion)
+	}
+	return total / float64(len(QualityCriteria))
+}
+
+// calculateQuality вычисляет метрики качества документации
+func (pr *ParseResult) calculateQuality() QualityStats {
+	stats := QualityStats{
+		Coverage: make(map[QualityCriterion]int),
+		Packages: make(map[string]float64),
+	}
+
+	var all []TestQuality
+	total := 0.0
+	for name, pkg := range pr.Packages {
+		if len(pkg.Tests) == 0 {
+			continue
+		}
+
+		packageTotal := 0.0
+		for _, test := range pkg.Tests {
+			quality := test.Quality()
+			if quality.Package == "" {
+				quality.Package = name
+			}
+			for _, c := range QualityCriteria {
+				if test.Satisfies(c.Criterion) {
+					stats.Coverage[c.Criterion]++
+				}
+			}
+			packageTotal += quality.Score
+			all = append(all, quality)
+		}
+		stats.Packages[name] = packageTotal / float64(len(pkg.Tests))
+		total += packageTotal
+	}
+
+	if len(all) == 0 {
+		return stats
+	}
+	stats.Score = total / float64(len(all))
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Score != all[j].Score {
+			return all[i].Score < all[j].Score
+		}
+		if all[i].Package != all[j].Package {
+			return all[i].Package < all[j].Package
+		}
+		return all[i].Name < all[j].Name
+	})
+	for _, quality := range all {
+		if quality.Score >= 100 || len(stats.Worst) == WorstTestsLimit {
+			break
+		}
+		stats.Worst = append(stats.Worst, quality)
+	}
+
+	return stats
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestInfo_Quality(t *testing.T) {
+	full := TestInfo{
+		Name:         "TestFull",
+		Description:  "Описание",
+		ExplicitType: true,
+		Author:       "Иван",
+		TestCases: []TestCase{
+			{Name: "шаги", Steps: []Step{{Action: "открыть"}}},
+			{Name: "сценарий", Scenario: []ScenarioStep{{Keyword: Given, Text: "пользователь"}}},
+		},
+	}
+	quality := full.Quality()
+	assert.Equal(t, 100.0, quality.Score)
+	assert.Empty(t, quality.Missing)
+
+	partial := TestInfo{
+		Name:        "TestPartial",
+		Description: "Описание",
+		TestCases:   []TestCase{{Name: "со шагами", Steps: []Step{{Action: "a"}}}, {Name: "без шагов"}},
+	}
+	quality = partial.Quality()
+	assert.Equal(t, 60.0, quality.Score)
+	assert.Equal(t, []QualityCriterion{CriterionType, CriterionAuthor, CriterionSteps}, quality.Missing)
+
+	quality = TestInfo{Name: "TestEmpty"}.Quality()
+	assert.Equal(t, 0.0, quality.Score)
+	assert.Len(t, quality.Missing, len(QualityCriteria))
+}
+
+func TestQualityCriteria_Weights(t *testing.T) {
+	total := 0.0
+	for _, c := range QualityCriteria {
+		total += c.Weight
+	}
+	assert.Equal(t, 100.0, total)
+}
+
+func TestParseResult_Quality(t *testing.T) {
+	result := &ParseResult{
+		Packages: map[string]*PackageInfo{
+			"good": {Tests: []TestInfo{
+				{Name: "TestA", Package: "good", Description: "a", ExplicitType: true, Author: "x",
+					TestCases: []TestCase{{Name: "c", Steps: []Step{{Action: "s"}}}}},
+			}},
+			"bad": {Tests: []TestInfo{
+				{Name: "TestB", Package: "bad", Description: "b"},
+				{Name: "TestC", Package: "bad"},
+			}},
+		},
+	}
+	result.CalculateStats()
+
+	quality := result.Stats.Quality
+	assert.InDelta(t, (100.0+35.0+0.0)/3, quality.Score, 0.001)
+	assert.Equal(t, 100.0, quality.Packages["good"])
+	assert.Equal(t, 17.5, quality.Packages["bad"])
+	assert.Equal(t, 2, quality.Coverage[CriterionDescription])
+	assert.Equal(t, 1, quality.Coverage[CriterionAuthor])
+	assert.InDelta(t, 66.67, result.Stats.CoveragePercent(CriterionDescription), 0.01)
+
+	require.Len(t, quality.Worst, 2)
+	assert.Equal(t, "TestC", quality.Worst[0].Name)
+	assert.Equal(t, "TestB", quality.Worst[1].Name)
+}
+
+func TestParseResult_Quality_WorstLimit(t *testing.T) {
+	var tests []TestInfo
+	for i := 0; i < WorstTestsLimit+5; i++ {
+		tests = append(tests, TestInfo{Name: string(rune('A' + i))})
+	}
+	result := &ParseResult{Packages: map[string]*PackageInfo{"p": {Tests: tests}}}
+	result.CalculateStats()
+
+	assert.Len(t, result.Stats.Quality.Worst, WorstTestsLimit)
+	assert.Equal(t, "p", result.Stats.Quality.Worst[0].Package)
+}
+
+func TestStatistics_AnnotationCoverage(t *testing.T) {
+	stats := Statistics{
+		TotalTests: 4,
+		Quality: QualityStats{Coverage: map[QualityCriterion]int{
+			CriterionDescription: 4,
+			CriterionType:        2,
+			CriterionAuthor:      2,
+		}},
+	}
+
+	// (100 + 50 + 50 + 0 + 0) / 5
+	assert.Equal(t, 40.0, stats.AnnotationCoverage())
+	assert.Equal(t, 0.0, Statistics{}.AnnotationCoverage())
+}
@@ -0,0 +1,162 @@
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// Priority определяет приоритет теста из аннотации @priority
+type Priority string
+
+const (
+	// P0 - критичный тест: без него релиз невозможен
+	P0 Priority = "P0"
+	// P1 - высокий приоритет
+	P1 Priority = "P1"
+	// P2 - средний приоритет
+	P2 Priority = "P2"
+	// P3 - низкий приоритет
+	P3 Priority = "P3"
+)
+
+// Priorities возвращает приоритеты от высшего к низшему
+func Priorities() []Priority {
+	return []Priority{P0, P1, P2, P3}
+}
+
+// ParsePriority разбирает значение @priority: P0..P3 в любом регистре или
+// critical (blocker), high, medium (normal), low
+func ParsePriority(value string) (Priority, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "p0", "critical", "blocker":
+		return P0, true
+	case "p1", "high":
+		return P1, true
+	case "p2", "medium", "normal":
+		return P2, true
+	case "p3", "low":
+		return P3, true
+	default:
+		return "", false
+	}
+}
+
+// Rank возвращает порядок приоритета: 0 для P0, 3 для P3 и 4 для пустого
+func (p Priority) Rank() int {
+	for i, priority := range Priorities() {
+		if p == priority {
+			return i
+		}
+	}
+	return len(Priorities())
+}
+
+// Severity определяет серьезность последствий отказа из аннотации @severity
+type Severity string
+
+const (
+	SeverityBlocker  Severity = "blocker"
+	SeverityCritical Severity = "critical"
+	SeverityMajor    Severity = "major"
+	SeverityMinor    Severity = "minor"
+	SeverityTrivial  Severity = "trivial"
+)
+
+// Severities возвращает уровни серьезности от высшего к низшему
+func Severities() []Severity {
+	return []Severity{SeverityBlocker, SeverityCritical, SeverityMajor, SeverityMinor, SeverityTrivial}
+}
+
+// ParseSeverity разбирает значение @severity без учета регистра
+func ParseSeverity(value string) (Severity, bool) {
+	severity := Severity(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range Severities() {
+		if severity == known {
+			return severity, true
+		}
+	}
+	return "", false
+}
+
+// Rank возвращает порядок серьезности: 0 для blocker, 4 для trivial и 5 для пустой
+func (s Severity) Rank() int {
+	for i, severity := range Severities() {
+		if s == severity {
+			return i
+		}
+	}
+	return len(Severities())
+}
+
+// HasSteps возвращает true, если хотя бы один тест-кейс описан шагами
+// @step или сценарием Given/When/Then
+func (t TestInfo) HasSteps() bool {
+	for _, testCase := range t.TestCases {
+		if len(testCase.Steps) > 0 || len(testCase.Scenario) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RiskReport содержит сведения о рисках релиза по приоритетам тестов
+type RiskReport struct {
+	// SkippedCritical - пропущенные тесты P0
+	SkippedCritical []TestInfo `json:"skipped_critical" yaml:"skipped_critical"`
+	// CriticalWithoutSteps - тесты P0 без шагов и сценариев
+	CriticalWithoutSteps []TestInfo `json:"critical_without_steps" yaml:"critical_without_steps"`
+	// Packages - распределение приоритетов по пакетам, по алфавиту
+	Packages []PackagePriorities `json:"packages" yaml:"packages"`
+}
+
+// PackagePriorities содержит количество тестов пакета по приоритетам
+type PackagePriorities struct {
+	Package string           `json:"package" yaml:"package"`
+	Counts  map[Priority]int `json:"counts" yaml:"counts"`
+	// Unprioritized - тесты без @priority
+	Unprioritized int `json:"unprioritized" yaml:"unprioritized"`
+}
+
+// Risk строит отчет о рисках: пропущенные тесты P0, тесты P0 без шагов и
+// распределение приоритетов по пакетам. Пакеты без тестов с приоритетом
+// в распределение не входят.
+func (r *ParseResult) Risk() RiskReport {
+	var report RiskReport
+
+	var names []string
+	for name := range r.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		pkg := PackagePriorities{Package: name, Counts: make(map[Priority]int)}
+		for _, test := range r.Packages[name].Tests {
+			if test.Priority == "" {
+				pkg.Unprioritized++
+				continue
+			}
+			pkg.Counts[test.Priority]++
+
+			if test.Priority != P0 {
+				continue
+			}
+			if test.Skipped {
+				report.SkippedCritical = append(report.SkippedCritical, test)
+			}
+			if !test.HasSteps() {
+				report.CriticalWithoutSteps = append(report.CriticalWithoutSteps, test)
+			}
+		}
+		if len(pkg.Counts) > 0 {
+			report.Packages = append(report.Packages, pkg)
+		}
+	}
+
+	return report
+}
+
+// Empty возвращает true, если ни у одного теста не задан приоритет
+func (r RiskReport) Empty() bool {
+	return len(r.Packages) == 0
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Priority
+		ok       bool
+	}{
+		{"P0", P0, true},
+		{" p1 ", P1, true},
+		{"critical", P0, true},
+		{"Blocker", P0, true},
+		{"high", P1, true},
+		{"normal", P2, true},
+		{"low", P3, true},
+		{"P4", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		priority, ok := ParsePriority(tt.value)
+		assert.Equal(t, tt.ok, ok, tt.value)
+		assert.Equal(t, tt.expected, priority, tt.value)
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	severity, ok := ParseSeverity(" Major ")
+	assert.True(t, ok)
+	assert.Equal(t, SeverityMajor, severity)
+
+	_, ok = ParseSeverity("catastrophic")
+	assert.False(t, ok)
+}
+
+func TestPriority_Rank(t *testing.T) {
+	assert.Equal(t, 0, P0.Rank())
+	assert.Equal(t, 3, P3.Rank())
+	assert.Equal(t, 4, Priority("").Rank())
+	assert.Equal(t, 0, SeverityBlocker.Rank())
+	assert.Equal(t, 5, Severity("").Rank())
+}
+
+func TestParseResult_Risk(t *testing.T) {
+	steps := []TestCase{{Name: "Оплата", Steps: []Step{{Action: "Оплатить"}}}}
+	scenario := []TestCase{{Name: "Возврат", Scenario: []ScenarioStep{{Keyword: Given, Text: "заказ оплачен"}}}}
+
+	result := &ParseResult{
+		Packages: map[string]*PackageInfo{
+			"billing": {
+				Tests: []TestInfo{
+					{Name: "TestCharge", Priority: P0, TestCases: steps},
+					{Name: "TestRefund", Priority: P0, TestCases: scenario, Skipped: true},
+					{Name: "TestInvoice", Priority: P0},
+					{Name: "TestReport", Priority: P2},
+					{Name: "TestExport"},
+				},
+			},
+			"api": {
+				Tests: []TestInfo{{Name: "TestRoutes", Priority: P1, Skipped: true}},
+			},
+			"docs": {
+				Tests: []TestInfo{{Name: "TestExamples"}},
+			},
+		},
+	}
+
+	report := result.Risk()
+	assert.False(t, report.Empty())
+
+	require.Len(t, report.SkippedCritical, 1)
+	assert.Equal(t, "TestRefund", report.SkippedCritical[0].Name)
+	require.Len(t, report.CriticalWithoutSteps, 1)
+	assert.Equal(t, "TestInvoice", report.CriticalWithoutSteps[0].Name)
+
+	// Пакеты без приоритетов в распределение не входят
+	require.Len(t, report.Packages, 2)
+	assert.Equal(t, PackagePriorities{Package: "api", Counts: map[Priority]int{P1: 1}}, report.Packages[0])
+	assert.Equal(t, PackagePriorities{
+		Package:       "billing",
+		Counts:        map[Priority]int{P0: 3, P2: 1},
+		Unprioritized: 1,
+	}, report.Packages[1])
+
+	empty := &ParseResult{Packages: map[string]*PackageInfo{"docs": {Tests: []TestInfo{{Name: "TestExamples"}}}}}
+	assert.True(t, empty.Risk().Empty())
+}
@@ -0,0 +1,109 @@
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// Виды ссылок на трекер, используемые в link_templates
+const (
+	LinkRequirement = "requirement"
+	LinkIssue       = "issue"
+	LinkStory       = "story"
+)
+
+// TraceStatus определяет статус покрытия требования тестами
+type TraceStatus string
+
+const (
+	// TraceCovered - требование покрыто хотя бы одним активным тестом
+	TraceCovered TraceStatus = "covered"
+	// TraceSkipped - все тесты требования пропущены
+	TraceSkipped TraceStatus = "skipped"
+	// TraceMissing - для требования нет ни одного теста
+	TraceMissing TraceStatus = "missing"
+)
+
+// TraceTest описывает тест, связанный с требованием
+type TraceTest struct {
+	Name    string `json:"name" yaml:"name"`
+	Package string `json:"package" yaml:"package"`
+	Skipped bool   `json:"skipped" yaml:"skipped"`
+}
+
+// TraceEntry представляет строку матрицы трассируемости: требование → тесты → статус
+type TraceEntry struct {
+	Requirement string      `json:"requirement" yaml:"requirement"`
+	Tests       []TraceTest `json:"tests" yaml:"tests"`
+	Status      TraceStatus `json:"status" yaml:"status"`
+}
+
+// Traceability строит матрицу трассируемости по аннотациям @requirement.
+// Требования из known, для которых не найдено тестов, попадают в матрицу
+// со статусом TraceMissing. Строки отсортированы по идентификатору требования.
+func (pr *ParseResult) Traceability(known []string) []TraceEntry {
+	entries := make(map[string]*TraceEntry)
+
+	for _, id := range known {
+		id = strings.TrimSpace(id)
+		if id != "" && entries[id] == nil {
+			entries[id] = &TraceEntry{Requirement: id, Tests: []TraceTest{}}
+		}
+	}
+
+	for _, pkg := range pr.Packages {
+		for _, test := range pkg.Tests {
+			for _, id := range test.Requirements {
+				if entries[id] == nil {
+					entries[id] = &TraceEntry{Requirement: id, Tests: []TraceTest{}}
+				}
+				entries[id].Tests = append(entries[id].Tests, TraceTest{
+					Name:    test.Name,
+					Package: test.Package,
+					Skipped: test.Skipped,
+				})
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(entries))
+	for id := range entries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	matrix := make([]TraceEntry, 0, len(ids))
+	for _, id := range ids {
+		entry := entries[id]
+		sort.Slice(entry.Tests, func(i, j int) bool {
+			if entry.Tests[i].Package != entry.Tests[j].Package {
+				return entry.Tests[i].Package < entry.Tests[j].Package
+			}
+			return entry.Tests[i].Name < entry.Tests[j].Name
+		})
+
+		entry.Status = TraceMissing
+		for _, test := range entry.Tests {
+			if !test.Skipped {
+				entry.Status = TraceCovered
+				break
+			}
+			entry.Status = TraceSkipped
+		}
+
+		matrix = append(matrix, *entry)
+	}
+
+	return matrix
+}
+
+// ExpandLink подставляет идентификатор в шаблон ссылки вида
+// "https://tracker.example.com/browse/{id}". Если шаблон не задан,
+// возвращается пустая строка.
+func (c *Config) ExpandLink(kind, id string) string {
+	template := c.LinkTemplates[kind]
+	if template == "" {
+		return ""
+	}
+	return strings.ReplaceAll(template, "{id}", id)
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResult_Traceability(t *testing.T) {
+	result := &ParseResult{
+		Packages: map[string]*PackageInfo{
+			"auth": {
+				Tests: []TestInfo{
+					{Name: "TestLogin", Package: "auth", Requirements: []string{"REQ-1", "REQ-2"}},
+					{Name: "TestLogout", Package: "auth", Requirements: []string{"REQ-2"}, Skipped: true},
+				},
+			},
+			"billing": {
+				Tests: []TestInfo{
+					{Name: "TestRefund", Package: "billing", Requirements: []string{"REQ-3"}, Skipped: true},
+				},
+			},
+		},
+	}
+
+	matrix := result.Traceability([]string{"REQ-1", "REQ-4", " "})
+	require.Len(t, matrix, 4)
+
+	assert.Equal(t, "REQ-1", matrix[0].Requirement)
+	assert.Equal(t, TraceCovered, matrix[0].Status)
+
+	assert.Equal(t, "REQ-2", matrix[1].Requirement)
+	assert.Equal(t, TraceCovered, matrix[1].Status)
+	assert.Equal(t, []TraceTest{
+		{Name: "TestLogin", Package: "auth"},
+		{Name: "TestLogout", Package: "auth", Skipped: true},
+	}, matrix[1].Tests)
+
+	assert.Equal(t, TraceSkipped, matrix[2].Status)
+
+	assert.Equal(t, "REQ-4", matrix[3].Requirement)
+	assert.Equal(t, TraceMissing, matrix[3].Status)
+	assert.Empty(t, matrix[3].Tests)
+}
+
+func TestConfig_ExpandLink(t *testing.T) {
+	config := &Config{
+		LinkTemplates: map[string]string{
+			LinkIssue: "https://tracker.example.com/browse/{id}",
+		},
+	}
+
+	assert.Equal(t, "https://tracker.example.com/browse/BUG-7", config.ExpandLink(LinkIssue, "BUG-7"))
+	assert.Equal(t, "", config.ExpandLink(LinkRequirement, "REQ-1"))
+}
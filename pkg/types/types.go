@@ -35,29 +35,112 @@ func (t TestType) IsValid() bool {
 
 // TestInfo содержит информацию о тесте
 type TestInfo struct {
-	Name        string            `json:"name" yaml:"name"`
-	Type        TestType          `json:"type" yaml:"type"`
-	Description string            `json:"description" yaml:"description"`
-	TestCases   []TestCase        `json:"test_cases" yaml:"test_cases"`
-	Skipped     bool              `json:"skipped" yaml:"skipped"`
-	SkipReason  string            `json:"skip_reason,omitempty" yaml:"skip_reason,omitempty"`
-	Package     string            `json:"package" yaml:"package"`
-	File        string            `json:"file" yaml:"file"`
-	Line        int               `json:"line" yaml:"line"`
-	Tags        []string          `json:"tags" yaml:"tags"`
-	Author      string            `json:"author,omitempty" yaml:"author,omitempty"`
-	Created     time.Time         `json:"created,omitempty" yaml:"created,omitempty"`
-	Updated     time.Time         `json:"updated,omitempty" yaml:"updated,omitempty"`
-	Metadata    map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Name         string            `json:"name" yaml:"name"`
+	Type         TestType          `json:"type" yaml:"type"`
+	ExplicitType bool              `json:"explicit_type,omitempty" yaml:"explicit_type,omitempty"`
+	Description  string            `json:"description" yaml:"description"`
+	TestCases    []TestCase        `json:"test_cases" yaml:"test_cases"`
+	Skipped      bool              `json:"skipped" yaml:"skipped"`
+	SkipReason   string            `json:"skip_reason,omitempty" yaml:"skip_reason,omitempty"`
+	Skips        []SkipInfo        `json:"skips,omitempty" yaml:"skips,omitempty"`
+	Dependencies []Dependency      `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	Traits       ExecutionTraits   `json:"traits" yaml:"traits,omitempty"`
+	Fingerprint  Fingerprint       `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+	Package      string            `json:"package" yaml:"package"`
+	File         string            `json:"file" yaml:"file"`
+	Line         int               `json:"line" yaml:"line"`
+	Tags         []string          `json:"tags" yaml:"tags"`
+	Author       string            `json:"author,omitempty" yaml:"author,omitempty"`
+	Priority     Priority          `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Severity     Severity          `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Owners       []string          `json:"owners,omitempty" yaml:"owners,omitempty"`
+	Requirements []string          `json:"requirements,omitempty" yaml:"requirements,omitempty"`
+	Issues       []string          `json:"issues,omitempty" yaml:"issues,omitempty"`
+	Stories      []string          `json:"stories,omitempty" yaml:"stories,omitempty"`
+	Created      time.Time         `json:"created,omitempty" yaml:"created,omitempty"`
+	Updated      time.Time         `json:"updated,omitempty" yaml:"updated,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Run          *RunResult        `json:"run,omitempty" yaml:"run,omitempty"`
+}
+
+// RunResult содержит итог последнего запуска теста из go test -json
+type RunResult struct {
+	// Outcome - итог выполнения: pass, fail или skip
+	Outcome string        `json:"outcome" yaml:"outcome"`
+	Elapsed time.Duration `json:"elapsed" yaml:"elapsed"`
+}
+
+// SkipKind определяет вид условия пропуска теста
+type SkipKind string
+
+const (
+	// SkipAlways - безусловный пропуск
+	SkipAlways SkipKind = "always"
+	// SkipShort - пропуск в режиме go test -short
+	SkipShort SkipKind = "short"
+	// SkipEnv - пропуск в зависимости от переменной окружения
+	SkipEnv SkipKind = "env"
+	// SkipOS - пропуск на определенной ОС (runtime.GOOS)
+	SkipOS SkipKind = "goos"
+	// SkipArch - пропуск на определенной архитектуре (runtime.GOARCH)
+	SkipArch SkipKind = "goarch"
+	// SkipBuildTag - тест собирается только с определенными build-тегами
+	SkipBuildTag SkipKind = "build_tag"
+	// SkipSubtest - пропуск внутри подтеста t.Run
+	SkipSubtest SkipKind = "subtest"
+	// SkipCondition - пропуск по произвольному условию
+	SkipCondition SkipKind = "condition"
+)
+
+// SkipInfo описывает один вызов t.Skip или ограничение сборки
+type SkipInfo struct {
+	Kind      SkipKind `json:"kind" yaml:"kind"`
+	Condition string   `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Detail    string   `json:"detail,omitempty" yaml:"detail,omitempty"`
+	Reason    string   `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Line      int      `json:"line,omitempty" yaml:"line,omitempty"`
+}
+
+// Conditional возвращает true, если пропуск происходит не всегда
+func (s SkipInfo) Conditional() bool {
+	return s.Kind != SkipAlways
+}
+
+// ConditionalSkips возвращает условные пропуски теста
+func (t TestInfo) ConditionalSkips() []SkipInfo {
+	var skips []SkipInfo
+	for _, skip := range t.Skips {
+		if skip.Conditional() {
+			skips = append(skips, skip)
+		}
+	}
+	return skips
+}
+
+// ExecutionTraits описывает особенности выполнения теста, найденные в его теле
+type ExecutionTraits struct {
+	Parallel bool     `json:"parallel,omitempty" yaml:"parallel,omitempty"`
+	Cleanup  bool     `json:"cleanup,omitempty" yaml:"cleanup,omitempty"`
+	Defer    bool     `json:"defer,omitempty" yaml:"defer,omitempty"`
+	Helpers  []string `json:"helpers,omitempty" yaml:"helpers,omitempty"`
+	Timeouts []string `json:"timeouts,omitempty" yaml:"timeouts,omitempty"`
+	Sleeps   []string `json:"sleeps,omitempty" yaml:"sleeps,omitempty"`
+}
+
+// Empty возвращает true, если особенности выполнения не обнаружены
+func (e ExecutionTraits) Empty() bool {
+	return !e.Parallel && !e.Cleanup && !e.Defer &&
+		len(e.Helpers) == 0 && len(e.Timeouts) == 0 && len(e.Sleeps) == 0
 }
 
 // TestCase представляет отдельный тест-кейс
 type TestCase struct {
-	Name        string `json:"name" yaml:"name"`
-	Description string `json:"description" yaml:"description"`
-	Input       string `json:"input,omitempty" yaml:"input,omitempty"`
-	Expected    string `json:"expected,omitempty" yaml:"expected,omitempty"`
-	Steps       []Step `json:"steps,omitempty" yaml:"steps,omitempty"`
+	Name        string         `json:"name" yaml:"name"`
+	Description string         `json:"description" yaml:"description"`
+	Input       string         `json:"input,omitempty" yaml:"input,omitempty"`
+	Expected    string         `json:"expected,omitempty" yaml:"expected,omitempty"`
+	Steps       []Step         `json:"steps,omitempty" yaml:"steps,omitempty"`
+	Scenario    []ScenarioStep `json:"scenario,omitempty" yaml:"scenario,omitempty"`
 }
 
 // Step представляет шаг в тест-кейсе
@@ -67,6 +150,23 @@ type Step struct {
 	Expected    string `json:"expected,omitempty" yaml:"expected,omitempty"`
 }
 
+// GherkinKeyword определяет ключевое слово шага сценария Given/When/Then
+type GherkinKeyword string
+
+const (
+	Given GherkinKeyword = "Given"
+	When  GherkinKeyword = "When"
+	Then  GherkinKeyword = "Then"
+	And   GherkinKeyword = "And"
+	But   GherkinKeyword = "But"
+)
+
+// ScenarioStep представляет шаг сценария в стиле Gherkin
+type ScenarioStep struct {
+	Keyword GherkinKeyword `json:"keyword" yaml:"keyword"`
+	Text    string         `json:"text" yaml:"text"`
+}
+
 // TestAnnotation представляет аннотацию в комментарии
 type TestAnnotation struct {
 	Type  string `json:"type" yaml:"type"`
@@ -89,12 +189,29 @@ type Config struct {
 	Author          string            `yaml:"author"`
 	Version         string            `yaml:"version"`
 	Language        string            `yaml:"language"`
+	Format          string            `yaml:"format"`
+	SiteMode        string            `yaml:"site_mode"`
+	AnchorStyle     string            `yaml:"anchor_style"`
 	IncludeSkipped  bool              `yaml:"include_skipped"`
 	GroupByType     bool              `yaml:"group_by_type"`
 	GroupByPackage  bool              `yaml:"group_by_package"`
+	GroupByOwner    bool              `yaml:"group_by_owner"`
+	GroupBy         []string          `yaml:"group_by"`
+	SortBy          string            `yaml:"sort_by"`
+	SortOrder       string            `yaml:"sort_order"`
+	CodeOwnersFile  string            `yaml:"codeowners_file"`
+	LinkTemplates   map[string]string `yaml:"link_templates"`
+	Traceability    bool              `yaml:"traceability"`
+	Charts          bool              `yaml:"charts"`
+	ChartsDir       string            `yaml:"charts_dir"`
+	Badges          BadgeConfig       `yaml:"badges"`
+	MinQuality      float64           `yaml:"min_quality"`
+	Requirements    []string          `yaml:"requirements"`
 	CustomTemplates map[string]string `yaml:"custom_templates"`
 	ExcludePatterns []string          `yaml:"exclude_patterns"`
 	IncludePatterns []string          `yaml:"include_patterns"`
+	Gitignore       bool              `yaml:"gitignore"`
+	FollowSymlinks  bool              `yaml:"follow_symlinks"`
 }
 
 // DefaultConfig возвращает конфигурацию по умолчанию
@@ -104,12 +221,18 @@ func DefaultConfig() *Config {
 		Author:          "Generated automatically",
 		Version:         "1.0.0",
 		Language:        "ru",
+		Format:          "markdown",
+		SiteMode:        "html",
+		AnchorStyle:     "github",
+		Badges:          DefaultBadgeConfig(),
 		IncludeSkipped:  true,
 		GroupByType:     true,
 		GroupByPackage:  false,
 		CustomTemplates: make(map[string]string),
+		LinkTemplates:   make(map[string]string),
 		ExcludePatterns: []string{},
 		IncludePatterns: []string{"*_test.go"},
+		Gitignore:       true,
 	}
 }
 
@@ -121,11 +244,31 @@ type ParseResult struct {
 
 // Statistics содержит статистику тестов
 type Statistics struct {
-	TotalTests       int              `json:"total_tests" yaml:"total_tests"`
-	ActiveTests      int              `json:"active_tests" yaml:"active_tests"`
-	SkippedTests     int              `json:"skipped_tests" yaml:"skipped_tests"`
-	PackageCount     int              `json:"package_count" yaml:"package_count"`
-	TypeDistribution map[TestType]int `json:"type_distribution" yaml:"type_distribution"`
+	TotalTests       int                   `json:"total_tests" yaml:"total_tests"`
+	ActiveTests      int                   `json:"active_tests" yaml:"active_tests"`
+	SkippedTests     int                   `json:"skipped_tests" yaml:"skipped_tests"`
+	ConditionalTests int                   `json:"conditional_tests" yaml:"conditional_tests"`
+	ParallelTests    int                   `json:"parallel_tests" yaml:"parallel_tests"`
+	TimeoutTests     int                   `json:"timeout_tests" yaml:"timeout_tests"`
+	SleepTests       int                   `json:"sleep_tests" yaml:"sleep_tests"`
+	Quality          QualityStats          `json:"quality" yaml:"quality"`
+	PackageCount     int                   `json:"package_count" yaml:"package_count"`
+	TypeDistribution map[TestType]int      `json:"type_distribution" yaml:"type_distribution"`
+	Owners           map[string]OwnerStats `json:"owners,omitempty" yaml:"owners,omitempty"`
+}
+
+// OwnerStats содержит статистику тестов одного владельца
+type OwnerStats struct {
+	TotalTests   int `json:"total_tests" yaml:"total_tests"`
+	SkippedTests int `json:"skipped_tests" yaml:"skipped_tests"`
+}
+
+// ParallelPercent возвращает долю тестов с t.Parallel() в процентах
+func (s Statistics) ParallelPercent() float64 {
+	if s.TotalTests == 0 {
+		return 0
+	}
+	return float64(s.ParallelTests) * 100 / float64(s.TotalTests)
 }
 
 // CalculateStats вычисляет статистику из результата парсинга
@@ -142,10 +285,36 @@ func (pr *ParseResult) CalculateStats() {
 				stats.SkippedTests++
 			} else {
 				stats.ActiveTests++
+				if len(test.ConditionalSkips()) > 0 {
+					stats.ConditionalTests++
+				}
 			}
 			stats.TypeDistribution[test.Type]++
+
+			if test.Traits.Parallel {
+				stats.ParallelTests++
+			}
+			if len(test.Traits.Timeouts) > 0 {
+				stats.TimeoutTests++
+			}
+			if len(test.Traits.Sleeps) > 0 {
+				stats.SleepTests++
+			}
+
+			for _, owner := range test.Owners {
+				if stats.Owners == nil {
+					stats.Owners = make(map[string]OwnerStats)
+				}
+				ownerStats := stats.Owners[owner]
+				ownerStats.TotalTests++
+				if test.Skipped {
+					ownerStats.SkippedTests++
+				}
+				stats.Owners[owner] = ownerStats
+			}
 		}
 	}
 
+	stats.Quality = pr.calculateQuality()
 	pr.Stats = stats
 }
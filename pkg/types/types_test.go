@@ -121,6 +121,69 @@ func TestParseResult_CalculateStats(t *testing.T) {
 	assert.Equal(t, 0, result.Stats.TypeDistribution[FunctionalTest])
 }
 
+func TestParseResult_CalculateStats_ConditionalTests(t *testing.T) {
+	short := SkipInfo{Kind: SkipShort, Condition: "testing.Short()"}
+
+	result := &ParseResult{
+		Packages: map[string]*PackageInfo{
+			"example": {
+				Name: "example",
+				Tests: []TestInfo{
+					{Name: "TestSlow", Type: UnitTest, Skips: []SkipInfo{short}},
+					{Name: "TestBroken", Type: UnitTest, Skipped: true, Skips: []SkipInfo{{Kind: SkipAlways}, short}},
+					{Name: "TestPlain", Type: UnitTest},
+				},
+			},
+		},
+	}
+
+	result.CalculateStats()
+
+	assert.Equal(t, 2, result.Stats.ActiveTests)
+	assert.Equal(t, 1, result.Stats.SkippedTests)
+	assert.Equal(t, 1, result.Stats.ConditionalTests)
+}
+
+func TestParseResult_CalculateStats_Traits(t *testing.T) {
+	result := &ParseResult{
+		Packages: map[string]*PackageInfo{
+			"example": {
+				Name: "example",
+				Tests: []TestInfo{
+					{Name: "TestA", Traits: ExecutionTraits{Parallel: true, Timeouts: []string{"time.Second"}}},
+					{Name: "TestB", Traits: ExecutionTraits{Parallel: true, Sleeps: []string{"time.Millisecond"}}},
+					{Name: "TestC"},
+					{Name: "TestD", Traits: ExecutionTraits{Defer: true}},
+				},
+			},
+		},
+	}
+
+	result.CalculateStats()
+
+	assert.Equal(t, 2, result.Stats.ParallelTests)
+	assert.Equal(t, 1, result.Stats.TimeoutTests)
+	assert.Equal(t, 1, result.Stats.SleepTests)
+	assert.InDelta(t, 50.0, result.Stats.ParallelPercent(), 0.001)
+	assert.Zero(t, Statistics{}.ParallelPercent())
+}
+
+func TestTestInfo_ConditionalSkips(t *testing.T) {
+	test := TestInfo{
+		Skips: []SkipInfo{
+			{Kind: SkipAlways, Reason: "broken"},
+			{Kind: SkipEnv, Detail: "DATABASE_URL"},
+			{Kind: SkipBuildTag, Condition: "integration"},
+		},
+	}
+
+	skips := test.ConditionalSkips()
+	assert.Len(t, skips, 2)
+	assert.Equal(t, SkipEnv, skips[0].Kind)
+	assert.Equal(t, SkipBuildTag, skips[1].Kind)
+	assert.Empty(t, TestInfo{}.ConditionalSkips())
+}
+
 func TestTestInfo_Creation(t *testing.T) {
 	now := time.Now()
 
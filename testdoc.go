@@ -30,12 +30,22 @@
 package testdoc
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"strings"
 
 	yaml "gopkg.in/yaml.v3"
 
+	"github.com/seblex/testdoc/pkg/badge"
+	"github.com/seblex/testdoc/pkg/config"
 	"github.com/seblex/testdoc/pkg/generator"
 	"github.com/seblex/testdoc/pkg/parser"
+	"github.com/seblex/testdoc/pkg/results"
+	"github.com/seblex/testdoc/pkg/site"
+	"github.com/seblex/testdoc/pkg/slug"
 	"github.com/seblex/testdoc/pkg/types"
 )
 
@@ -44,20 +54,38 @@ func DefaultConfig() *types.Config {
 	return types.DefaultConfig()
 }
 
-// LoadConfig загружает конфигурацию из YAML файла
+// LoadConfig загружает конфигурацию из YAML файла. Ключи, отсутствующие
+// в файле, сохраняют значения по умолчанию.
 func LoadConfig(filename string) (*types.Config, error) {
+	return config.LoadFile(filename)
+}
+
+// ResolveConfig собирает конфигурацию из слоев: значения по умолчанию,
+// пользовательский файл, файлы .testdoc.yaml от корня репозитория до dir
+// (или явно указанный file) и переменные окружения TESTDOC_*
+func ResolveConfig(dir, file string) (*config.Effective, error) {
+	return config.Load(config.Options{Dir: dir, File: file})
+}
+
+// LoadRequirements загружает список идентификаторов требований из текстового файла.
+// Идентификатор - первое слово каждой строки; пустые строки и строки,
+// начинающиеся с #, пропускаются.
+func LoadRequirements(filename string) ([]string, error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
 
-	var config types.Config
-	err = yaml.Unmarshal(data, &config)
-	if err != nil {
-		return nil, err
+	var requirements []string
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		requirements = append(requirements, strings.TrimSuffix(fields[0], ","))
 	}
 
-	return &config, nil
+	return requirements, nil
 }
 
 // SaveConfig сохраняет конфигурацию в YAML файл
@@ -80,12 +108,40 @@ func ParseDirectory(path string, config *types.Config) (*types.ParseResult, erro
 	return p.ParseDirectory(path, config)
 }
 
+// ParseRevision анализирует директорию dir в состоянии на git ревизию rev
+// (ветку, тег или коммит) без переключения рабочей копии
+func ParseRevision(dir, rev string, config *types.Config) (*types.ParseResult, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	p := parser.New()
+	return p.ParseRevision(dir, rev, config)
+}
+
 // ParseFile анализирует один тест-файл и возвращает информацию о тестах
 func ParseFile(filename string) ([]types.TestInfo, error) {
 	p := parser.New()
 	return p.ParseFile(filename)
 }
 
+// ParseFS анализирует тест-файлы файловой системы fsys, начиная с директории root.
+// Подходит для встроенных testdata (embed.FS), zip архивов и наложений в памяти.
+func ParseFS(fsys fs.FS, root string, config *types.Config) (*types.ParseResult, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	p := parser.New()
+	return p.ParseFS(fsys, root, config)
+}
+
+// ParseSource анализирует исходный код тест-файла из памяти
+func ParseSource(name string, src []byte) ([]types.TestInfo, error) {
+	p := parser.New()
+	return p.ParseSource(name, src)
+}
+
 // GenerateMarkdown генерирует Markdown документацию из результата парсинга
 func GenerateMarkdown(result *types.ParseResult, config *types.Config) string {
 	if config == nil {
@@ -96,6 +152,99 @@ func GenerateMarkdown(result *types.ParseResult, config *types.Config) string {
 	return g.GenerateMarkdown(result)
 }
 
+// Render записывает документацию в w в формате config.Format
+func Render(w io.Writer, result *types.ParseResult, config *types.Config) error {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	if config.Format == site.Format {
+		return fmt.Errorf("формат %s записывает директорию, используйте WriteSite", site.Format)
+	}
+
+	renderer, err := generator.NewRenderer(config.Format, config)
+	if err != nil {
+		return err
+	}
+	return renderer.Render(w, result)
+}
+
+// WriteSite записывает многостраничный сайт документации в директорию dir
+// в режиме config.SiteMode (html или markdown)
+func WriteSite(result *types.ParseResult, dir string, config *types.Config) error {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	s, err := site.New(config, config.SiteMode)
+	if err != nil {
+		return err
+	}
+	return s.Write(dir, result)
+}
+
+// RenderToFile записывает документацию в файл в формате config.Format.
+// Для формата site filename - директория сайта.
+func RenderToFile(result *types.ParseResult, filename string, config *types.Config) error {
+	if config != nil && config.Format == site.Format {
+		return WriteSite(result, filename, config)
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := Render(f, result, config); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteFeatures записывает сценарии тестов в файлы Cucumber .feature, по одному на пакет
+func WriteFeatures(result *types.ParseResult, dir string, config *types.Config) error {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	g := generator.New(config)
+	return g.WriteFeatures(dir, result)
+}
+
+// WriteCharts записывает диаграммы распределения и структуры тестов
+// в файлы Mermaid (.mmd) и Graphviz (.dot)
+func WriteCharts(result *types.ParseResult, dir string, config *types.Config) error {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	g := generator.New(config)
+	return g.WriteCharts(dir, result)
+}
+
+// CheckQuality проверяет, что оценка качества документации проекта не ниже min.
+// Возвращает ошибку со списком худших тестов, если порог не достигнут.
+func CheckQuality(result *types.ParseResult, min float64) error {
+	quality := result.Stats.Quality
+	if quality.Score >= min {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("оценка качества документации %.1f ниже порога %.1f", quality.Score, min))
+	for _, test := range quality.Worst {
+		sb.WriteString(fmt.Sprintf("\n  - %s.%s: %.0f", test.Package, test.Name, test.Score))
+	}
+	return errors.New(sb.String())
+}
+
+// WriteBadges записывает SVG бейджи метрик тестов в директорию dir.
+// Если report не nil, добавляется бейдж доли прошедших тестов.
+func WriteBadges(result *types.ParseResult, dir string, config *types.Config, report *results.Report) error {
+	return badge.Write(dir, badge.Inventory(result, config, report))
+}
+
 // GenerateFromDirectory анализирует директорию и генерирует документацию
 func GenerateFromDirectory(path string, config *types.Config) (string, error) {
 	result, err := ParseDirectory(path, config)
@@ -106,6 +255,17 @@ func GenerateFromDirectory(path string, config *types.Config) (string, error) {
 	return GenerateMarkdown(result, config), nil
 }
 
+// GenerateTo анализирует директорию и записывает документацию в w в формате
+// config.Format по мере генерации, без построения документа в памяти
+func GenerateTo(path string, w io.Writer, config *types.Config) error {
+	result, err := ParseDirectory(path, config)
+	if err != nil {
+		return err
+	}
+
+	return Render(w, result, config)
+}
+
 // WriteToFile записывает документацию в файл
 func WriteToFile(content, filename string) error {
 	return os.WriteFile(filename, []byte(content), 0644)
@@ -123,33 +283,70 @@ func AppendToFile(content, filename string) error {
 	return err
 }
 
-// ValidateConfig проверяет корректность конфигурации
-func ValidateConfig(config *types.Config) error {
-	if config.Title == "" {
-		config.Title = "Test Documentation"
+// ValidateConfig заполняет незаданные параметры значениями по умолчанию и
+// проверяет конфигурацию. Ошибки возвращаются списком config.Errors.
+func ValidateConfig(cfg *types.Config) error {
+	if cfg.Title == "" {
+		cfg.Title = "Test Documentation"
+	}
+	if cfg.Author == "" {
+		cfg.Author = "Generated automatically"
+	}
+	if cfg.Version == "" {
+		cfg.Version = "1.0.0"
 	}
-	if config.Author == "" {
-		config.Author = "Generated automatically"
+	if cfg.Language == "" {
+		cfg.Language = "ru"
 	}
-	if config.Version == "" {
-		config.Version = "1.0.0"
+	if cfg.Format == "" {
+		cfg.Format = generator.FormatMarkdown
 	}
-	if config.Language == "" {
-		config.Language = "ru"
+	if cfg.SiteMode == "" {
+		cfg.SiteMode = site.ModeHTML
 	}
+	if cfg.AnchorStyle == "" {
+		cfg.AnchorStyle = string(slug.GitHub)
+	}
+	validateBadges(&cfg.Badges)
 
 	// Инициализируем пустые слайсы если они nil
-	if config.ExcludePatterns == nil {
-		config.ExcludePatterns = []string{}
+	if cfg.ExcludePatterns == nil {
+		cfg.ExcludePatterns = []string{}
 	}
-	if config.IncludePatterns == nil {
-		config.IncludePatterns = []string{"*_test.go"}
+	if cfg.IncludePatterns == nil {
+		cfg.IncludePatterns = []string{"*_test.go"}
 	}
-	if config.CustomTemplates == nil {
-		config.CustomTemplates = make(map[string]string)
+	if cfg.CustomTemplates == nil {
+		cfg.CustomTemplates = make(map[string]string)
 	}
 
-	return nil
+	return config.Validate(cfg)
+}
+
+// validateBadges заполняет незаданные настройки бейджей значениями по умолчанию.
+// Пороги заполняются, только если секция badges не задана целиком.
+func validateBadges(badges *types.BadgeConfig) {
+	defaults := types.DefaultBadgeConfig()
+	if *badges == (types.BadgeConfig{}) {
+		*badges = defaults
+		return
+	}
+
+	if badges.Dir == "" {
+		badges.Dir = defaults.Dir
+	}
+	if badges.Color == "" {
+		badges.Color = defaults.Color
+	}
+	if badges.Colors.Good == "" {
+		badges.Colors.Good = defaults.Colors.Good
+	}
+	if badges.Colors.Warning == "" {
+		badges.Colors.Warning = defaults.Colors.Warning
+	}
+	if badges.Colors.Bad == "" {
+		badges.Colors.Bad = defaults.Colors.Bad
+	}
 }
 
 // GetSupportedTestTypes возвращает список поддерживаемых типов тестов
@@ -322,6 +519,97 @@ func (f *Filter) ByAuthor(result *types.ParseResult, author string) *types.Parse
 	return filtered
 }
 
+// ByOwner фильтрует тесты по владельцу из CODEOWNERS или аннотации @owner.
+// Сравнение не зависит от регистра и от ведущего символа @.
+func (f *Filter) ByOwner(result *types.ParseResult, owner string) *types.ParseResult {
+	return f.filterTests(result, func(test types.TestInfo) bool {
+		for _, testOwner := range test.Owners {
+			if normalizeOwner(testOwner) == normalizeOwner(owner) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Unowned оставляет только тесты без владельца
+func (f *Filter) Unowned(result *types.ParseResult) *types.ParseResult {
+	return f.filterTests(result, func(test types.TestInfo) bool {
+		return len(test.Owners) == 0
+	})
+}
+
+// ByPriority оставляет тесты с одним из приоритетов @priority
+func (f *Filter) ByPriority(result *types.ParseResult, priorities ...types.Priority) *types.ParseResult {
+	return f.filterTests(result, func(test types.TestInfo) bool {
+		for _, priority := range priorities {
+			if test.Priority == priority {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// BySeverity оставляет тесты с одним из уровней серьезности @severity
+func (f *Filter) BySeverity(result *types.ParseResult, severities ...types.Severity) *types.ParseResult {
+	return f.filterTests(result, func(test types.TestInfo) bool {
+		for _, severity := range severities {
+			if test.Severity == severity {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// filterTests оставляет в результате только тесты, удовлетворяющие условию
+func (f *Filter) filterTests(result *types.ParseResult, match func(types.TestInfo) bool) *types.ParseResult {
+	filtered := &types.ParseResult{
+		Packages: make(map[string]*types.PackageInfo),
+	}
+
+	for pkgName, pkg := range result.Packages {
+		filteredPkg := &types.PackageInfo{
+			Name:        pkg.Name,
+			Path:        pkg.Path,
+			Description: pkg.Description,
+			Tests:       []types.TestInfo{},
+			TestTypes:   []types.TestType{},
+		}
+
+		for _, test := range pkg.Tests {
+			if !match(test) {
+				continue
+			}
+			filteredPkg.Tests = append(filteredPkg.Tests, test)
+
+			found := false
+			for _, t := range filteredPkg.TestTypes {
+				if t == test.Type {
+					found = true
+					break
+				}
+			}
+			if !found {
+				filteredPkg.TestTypes = append(filteredPkg.TestTypes, test.Type)
+			}
+		}
+
+		if len(filteredPkg.Tests) > 0 {
+			filtered.Packages[pkgName] = filteredPkg
+		}
+	}
+
+	filtered.CalculateStats()
+	return filtered
+}
+
+// normalizeOwner приводит имя владельца к виду для сравнения
+func normalizeOwner(owner string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(owner), "@"))
+}
+
 // hasAnyTag проверяет, есть ли у теста хотя бы один из указанных тегов
 func (f *Filter) hasAnyTag(testTags, filterTags []string) bool {
 	for _, filterTag := range filterTags {
@@ -3,11 +3,15 @@ package testdoc
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/seblex/testdoc/pkg/config"
+	"github.com/seblex/testdoc/pkg/results"
 	"github.com/seblex/testdoc/pkg/types"
 )
 
@@ -70,6 +74,55 @@ func TestLoadConfig_NonExistentFile(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestLoadConfig_KeepsDefaults(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte("title: \"Только заголовок\"\n"), 0644))
+
+	config, err := LoadConfig(filename)
+	require.NoError(t, err)
+	assert.Equal(t, "Только заголовок", config.Title)
+	assert.True(t, config.IncludeSkipped)
+	assert.Equal(t, []string{"*_test.go"}, config.IncludePatterns)
+}
+
+func TestResolveConfig(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".testdoc.yaml"), []byte("language: en\n"), 0644))
+	t.Setenv("TESTDOC_TITLE", "Из окружения")
+	// Изолируем пользовательский файл конфигурации
+	t.Setenv("HOME", root)
+	t.Setenv("XDG_CONFIG_HOME", root)
+
+	effective, err := ResolveConfig(root, "")
+	require.NoError(t, err)
+	assert.Equal(t, "en", effective.Config.Language)
+	assert.Equal(t, "Из окружения", effective.Config.Title)
+	assert.Equal(t, "$TESTDOC_TITLE", effective.Source("title"))
+}
+
+func TestLoadRequirements(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "testdoc_requirements_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	content := `# Требования релиза 2.0
+REQ-1 Вход по паролю
+REQ-2, Выход
+
+REQ-3
+`
+	filename := filepath.Join(tmpDir, "requirements.txt")
+	require.NoError(t, os.WriteFile(filename, []byte(content), 0644))
+
+	requirements, err := LoadRequirements(filename)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"REQ-1", "REQ-2", "REQ-3"}, requirements)
+
+	_, err = LoadRequirements(filepath.Join(tmpDir, "missing.txt"))
+	assert.Error(t, err)
+}
+
 func TestSaveConfig(t *testing.T) {
 	config := &types.Config{
 		Title:           "Test Config",
@@ -187,6 +240,34 @@ func TestIntegration(t *testing.T) {
 	assert.Equal(t, 1, result.Stats.PackageCount)
 }
 
+func TestParseFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"pkg/example_test.go": {Data: []byte(`package testpkg
+
+import "testing"
+
+// @type: integration
+func TestIntegration(t *testing.T) {}
+`)},
+	}
+
+	result, err := ParseFS(fsys, ".", nil)
+	require.NoError(t, err)
+	require.Contains(t, result.Packages, "testpkg")
+	assert.Equal(t, "pkg", result.Packages["testpkg"].Path)
+	assert.Equal(t, 1, result.Stats.TypeDistribution[types.IntegrationTest])
+}
+
+func TestParseSource(t *testing.T) {
+	tests, err := ParseSource("example_test.go", []byte("package testpkg\n\nfunc TestExample(t *testing.T) {}\n"))
+	require.NoError(t, err)
+	require.Len(t, tests, 1)
+	assert.Equal(t, "TestExample", tests[0].Name)
+
+	_, err = ParseSource("broken_test.go", []byte("func"))
+	assert.Error(t, err)
+}
+
 func TestGenerateFromDirectory(t *testing.T) {
 	// Создаем временную директорию с тест-файлами
 	tmpDir, err := os.MkdirTemp("", "testdoc_generate_test")
@@ -518,6 +599,61 @@ func TestFilter_ByAuthor(t *testing.T) {
 	assert.Equal(t, 2, filtered.Stats.TotalTests)
 }
 
+func TestFilter_ByOwner(t *testing.T) {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"pkg1": {
+				Name: "pkg1",
+				Tests: []types.TestInfo{
+					{Name: "TestPayments", Type: types.UnitTest, Owners: []string{"@org/Payments"}},
+					{Name: "TestShared", Type: types.IntegrationTest, Owners: []string{"@org/core", "@org/payments"}},
+					{Name: "TestOrphan", Type: types.UnitTest},
+				},
+			},
+		},
+	}
+
+	filter := NewFilter()
+
+	filtered := filter.ByOwner(result, "org/payments")
+	require.Contains(t, filtered.Packages, "pkg1")
+	assert.Len(t, filtered.Packages["pkg1"].Tests, 2)
+	assert.ElementsMatch(t, []types.TestType{types.UnitTest, types.IntegrationTest}, filtered.Packages["pkg1"].TestTypes)
+
+	unowned := filter.Unowned(result)
+	require.Len(t, unowned.Packages["pkg1"].Tests, 1)
+	assert.Equal(t, "TestOrphan", unowned.Packages["pkg1"].Tests[0].Name)
+}
+
+func TestFilter_ByPriorityAndSeverity(t *testing.T) {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"pkg1": {
+				Name: "pkg1",
+				Tests: []types.TestInfo{
+					{Name: "TestCharge", Type: types.UnitTest, Priority: types.P0, Severity: types.SeverityBlocker},
+					{Name: "TestRefund", Type: types.UnitTest, Priority: types.P1, Severity: types.SeverityMajor},
+					{Name: "TestReport", Type: types.UnitTest, Priority: types.P3},
+					{Name: "TestExport", Type: types.UnitTest},
+				},
+			},
+		},
+	}
+
+	filter := NewFilter()
+
+	filtered := filter.ByPriority(result, types.P0, types.P1)
+	require.Contains(t, filtered.Packages, "pkg1")
+	assert.Len(t, filtered.Packages["pkg1"].Tests, 2)
+	assert.Equal(t, 2, filtered.Stats.TotalTests)
+
+	filtered = filter.BySeverity(result, types.SeverityBlocker)
+	require.Len(t, filtered.Packages["pkg1"].Tests, 1)
+	assert.Equal(t, "TestCharge", filtered.Packages["pkg1"].Tests[0].Name)
+
+	assert.Empty(t, filter.ByPriority(result, types.P2).Packages)
+}
+
 func TestValidateConfig_Language(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -553,3 +689,201 @@ func TestValidateConfig_Language(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig_Errors(t *testing.T) {
+	cfg := &types.Config{Language: "eng", ExcludePatterns: []string{"[a-"}}
+
+	err := ValidateConfig(cfg)
+	var errs config.Errors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "language", errs[0].Key)
+	assert.Contains(t, errs[0].Message, `возможно, имелось в виду "en"`)
+	assert.Equal(t, "exclude_patterns[0]", errs[1].Key)
+}
+
+func TestValidateConfig_Format(t *testing.T) {
+	config := &types.Config{}
+	require.NoError(t, ValidateConfig(config))
+	assert.Equal(t, "markdown", config.Format)
+
+	config = &types.Config{Format: "json"}
+	require.NoError(t, ValidateConfig(config))
+
+	err := ValidateConfig(&types.Config{Format: "pdf"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"pdf"`)
+	assert.Contains(t, err.Error(), "json, markdown")
+}
+
+func TestRender(t *testing.T) {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"example": {Name: "example", Tests: []types.TestInfo{{Name: "TestA", Type: types.UnitTest, Package: "example"}}},
+		},
+	}
+	result.CalculateStats()
+
+	var sb strings.Builder
+	require.NoError(t, Render(&sb, result, &types.Config{Format: "json"}))
+	assert.Contains(t, sb.String(), `"name": "TestA"`)
+
+	sb.Reset()
+	config := DefaultConfig()
+	require.NoError(t, Render(&sb, result, config))
+	assert.Contains(t, sb.String(), "### TestA")
+
+	err := Render(&sb, result, &types.Config{Format: "pdf"})
+	assert.Error(t, err)
+}
+
+func TestRenderToFile(t *testing.T) {
+	result := &types.ParseResult{Packages: map[string]*types.PackageInfo{}}
+	filename := filepath.Join(t.TempDir(), "tests.json")
+
+	require.NoError(t, RenderToFile(result, filename, &types.Config{Format: "json"}))
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"packages": {}`)
+}
+
+func TestGenerateTo(t *testing.T) {
+	tmpDir := t.TempDir()
+	testCode := `package example
+
+import "testing"
+
+// @type: unit
+func TestStream(t *testing.T) {}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "stream_test.go"), []byte(testCode), 0644))
+
+	var sb strings.Builder
+	require.NoError(t, GenerateTo(tmpDir, &sb, nil))
+	assert.Contains(t, sb.String(), "### TestStream")
+
+	sb.Reset()
+	require.NoError(t, GenerateTo(tmpDir, &sb, &types.Config{Format: "json", IncludePatterns: []string{"*_test.go"}}))
+	assert.Contains(t, sb.String(), `"name": "TestStream"`)
+
+	assert.Error(t, GenerateTo(filepath.Join(tmpDir, "missing"), &sb, nil))
+}
+
+func TestValidateConfig_Site(t *testing.T) {
+	config := &types.Config{Format: "site"}
+	require.NoError(t, ValidateConfig(config))
+	assert.Equal(t, "html", config.SiteMode)
+
+	require.NoError(t, ValidateConfig(&types.Config{Format: "site", SiteMode: "markdown"}))
+
+	err := ValidateConfig(&types.Config{Format: "site", SiteMode: "pdf"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"pdf"`)
+}
+
+func TestWriteSite(t *testing.T) {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"example": {Name: "example", Tests: []types.TestInfo{{Name: "TestA", Type: types.UnitTest, Package: "example"}}},
+		},
+	}
+	result.CalculateStats()
+
+	dir := filepath.Join(t.TempDir(), "site")
+	config := DefaultConfig()
+	config.Format = "site"
+	config.SiteMode = "markdown"
+	require.NoError(t, RenderToFile(result, dir, config))
+
+	assert.FileExists(t, filepath.Join(dir, "index.md"))
+	assert.FileExists(t, filepath.Join(dir, "packages", "example.md"))
+	assert.FileExists(t, filepath.Join(dir, "search-index.json"))
+
+	var sb strings.Builder
+	assert.Error(t, Render(&sb, result, config))
+}
+
+func TestValidateConfig_AnchorStyle(t *testing.T) {
+	config := &types.Config{}
+	require.NoError(t, ValidateConfig(config))
+	assert.Equal(t, "github", config.AnchorStyle)
+
+	require.NoError(t, ValidateConfig(&types.Config{AnchorStyle: "gitlab"}))
+
+	err := ValidateConfig(&types.Config{AnchorStyle: "bitbucket"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"bitbucket"`)
+}
+
+func TestWriteCharts(t *testing.T) {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"example": {Name: "example", Tests: []types.TestInfo{{Name: "TestA", Type: types.UnitTest}}},
+		},
+	}
+	result.CalculateStats()
+
+	dir := filepath.Join(t.TempDir(), "charts")
+	require.NoError(t, WriteCharts(result, dir, nil))
+
+	for _, name := range []string{"types.mmd", "status.mmd", "packages.mmd", "hierarchy.mmd", "hierarchy.dot"} {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+}
+
+func TestValidateConfig_Badges(t *testing.T) {
+	config := &types.Config{}
+	require.NoError(t, ValidateConfig(config))
+	assert.Equal(t, types.DefaultBadgeConfig(), config.Badges)
+
+	config = &types.Config{Badges: types.BadgeConfig{Color: "green", Skipped: types.Threshold{Good: 0, Warning: 0}}}
+	require.NoError(t, ValidateConfig(config))
+	assert.Equal(t, "badges", config.Badges.Dir)
+	assert.Equal(t, "green", config.Badges.Color)
+	assert.Equal(t, "red", config.Badges.Colors.Bad)
+	assert.Equal(t, types.Threshold{}, config.Badges.Skipped)
+}
+
+func TestWriteBadges(t *testing.T) {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"example": {Name: "example", Tests: []types.TestInfo{{Name: "TestA", Type: types.UnitTest}}},
+		},
+	}
+	result.CalculateStats()
+
+	dir := filepath.Join(t.TempDir(), "badges")
+	report, err := results.Parse(strings.NewReader(`{"Action":"pass","Package":"p","Test":"TestA"}` + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, WriteBadges(result, dir, DefaultConfig(), report))
+
+	for _, name := range []string{"tests.svg", "skipped.svg", "coverage.svg", "type-unit.svg", "pass-rate.svg"} {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+}
+
+func TestCheckQuality(t *testing.T) {
+	result := &types.ParseResult{
+		Packages: map[string]*types.PackageInfo{
+			"example": {Tests: []types.TestInfo{
+				{Name: "TestA", Package: "example", Description: "Описание"},
+			}},
+		},
+	}
+	result.CalculateStats()
+
+	assert.NoError(t, CheckQuality(result, 0))
+	assert.NoError(t, CheckQuality(result, 35))
+
+	err := CheckQuality(result, 70)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "35.0 ниже порога 70.0")
+	assert.Contains(t, err.Error(), "example.TestA: 35")
+}
+
+func TestValidateConfig_MinQuality(t *testing.T) {
+	require.NoError(t, ValidateConfig(&types.Config{MinQuality: 70}))
+	assert.Error(t, ValidateConfig(&types.Config{MinQuality: 120}))
+	assert.Error(t, ValidateConfig(&types.Config{MinQuality: -1}))
+}